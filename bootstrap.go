@@ -20,6 +20,8 @@ package pkg
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"os"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/crypto"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/events"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/health"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/registry"
@@ -35,10 +38,11 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/storage"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/trace"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/worker"
+	healthgo "github.com/hellofresh/health-go/v5"
 	"go-micro.dev/v4"
+	microcache "go-micro.dev/v4/cache"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
-	"golang.org/x/sync/errgroup"
 )
 
 type option func(*options)
@@ -84,6 +88,17 @@ func NewBootstrapper(path string, opts ...option) bootstrapper {
 	}
 }
 
+// newHealthChecks registers cache and broker checks with the repl
+// service. A cache failure reports the service as degraded while
+// a broker failure reports it as down.
+func newHealthChecks(c microcache.Cache, broker messaging.BrokerWithOptions) []healthgo.Config {
+	cachePinger, _ := c.(health.Pinger)
+	return health.NewAggregator(
+		health.Component{Name: fmt.Sprintf("cache:%s", c.String()), Pinger: cachePinger},
+		health.Component{Name: fmt.Sprintf("broker:%s", broker.Broker.String()), Pinger: broker, Critical: true},
+	).Checks()
+}
+
 func (b bootstrapper) Bootstrap() *fx.App {
 	builder := config.BuildNewServerConfig(b.path)
 	sconf, err := builder()
@@ -102,6 +117,7 @@ func (b bootstrapper) Bootstrap() *fx.App {
 
 	return fx.New(
 		fx.Provide(config.BuildNewCacheConfig(b.path)),
+		fx.Provide(config.BuildNewClientConfig(b.path)),
 		fx.Provide(config.BuildNewCorsConfig(b.path)),
 		fx.Provide(config.BuildNewLoggerConfig(b.path)),
 		fx.Provide(config.BuildNewMessagingConfig(b.path)),
@@ -112,35 +128,73 @@ func (b bootstrapper) Bootstrap() *fx.App {
 		fx.Provide(config.BuildNewTracerConfig(b.path)),
 		fx.Provide(config.BuildNewWorkerConfig(b.path)),
 		fx.Provide(config.BuildNewCryptoConfig(b.path)),
+		fx.Provide(config.BuildNewEventsConfig(b.path)),
 		fx.Provide(cache.NewCache),
 		fx.Provide(log.NewLogrusLogger),
 		fx.Provide(registry.NewRegistry),
 		fx.Provide(messaging.NewBroker),
+		fx.Provide(messaging.NewPublisher),
 		fx.Provide(client.NewClient),
 		fx.Provide(trace.NewTracer),
 		fx.Provide(worker.NewBackgroundWorker),
 		fx.Provide(worker.NewBackgroundEnqueuer),
+		fx.Provide(worker.NewWorker),
 		fx.Provide(events.NewEmitter),
-		fx.Provide(repl.NewService),
+		fx.Provide(fx.Annotate(
+			newHealthChecks,
+			fx.ResultTags(`group:"health_checks,flatten"`),
+		)),
+		fx.Provide(fx.Annotate(
+			repl.NewService,
+			fx.ParamTags(``, ``, ``, ``, `group:"health_checks"`),
+		)),
 		fx.Provide(crypto.NewEncryptor),
 		fx.Provide(crypto.NewJwtManager),
 		fx.Provide(crypto.NewHasher),
+		fx.Provide(crypto.NewKeyedHasher),
 		fx.Provide(storage.NewStorage),
 		fx.Provide(b.modules...),
 		fx.Invoke(b.invokables...),
-		fx.Invoke(func(lifecycle fx.Lifecycle, service micro.Service, repl *http.Server, logger log.Logger) {
+		fx.Invoke(func(lifecycle fx.Lifecycle, broker messaging.BrokerWithOptions) {
 			lifecycle.Append(fx.Hook{
 				OnStart: func(ctx context.Context) error {
-					go repl.ListenAndServe()
-					go service.Run()
-					return nil
+					return broker.Connect()
+				},
+				OnStop: func(ctx context.Context) error {
+					return broker.Disconnect()
 				},
+			})
+		}),
+		fx.Invoke(func(lifecycle fx.Lifecycle, emitter events.Emitter) {
+			lifecycle.Append(fx.Hook{
 				OnStop: func(ctx context.Context) error {
-					g, gCtx := errgroup.WithContext(ctx)
-					g.Go(func() error {
-						return repl.Shutdown(gCtx)
-					})
-					return g.Wait()
+					closer, ok := emitter.(io.Closer)
+					if !ok {
+						return nil
+					}
+
+					done := make(chan error, 1)
+					go func() {
+						done <- closer.Close()
+					}()
+
+					select {
+					case err := <-done:
+						return err
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				},
+			})
+		}),
+		fx.Invoke(func(lifecycle fx.Lifecycle, srv *http.Server, config *config.ServerConfig) {
+			repl.StartRepl(lifecycle, srv, config.ShutdownTimeout)
+		}),
+		fx.Invoke(func(lifecycle fx.Lifecycle, service micro.Service, logger log.Logger) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go service.Run()
+					return nil
 				},
 			})
 		}),
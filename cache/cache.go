@@ -30,9 +30,14 @@ import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/eko/gocache/lib/v4/marshaler"
 	"github.com/eko/gocache/lib/v4/store"
+	"github.com/vmihailenco/msgpack/v5"
 	"go-micro.dev/v4/cache"
+	"golang.org/x/sync/singleflight"
 )
 
+// _pingKey is a probe key looked up by Ping.
+const _pingKey = "health:ping"
+
 // A CustomCache provides go-micro compatible interface for
 // custom cache providers. This structure is expected to be
 // initialized automatically by fx.
@@ -42,35 +47,167 @@ type CustomCache struct {
 	// Name is name for config based
 	// initialization.
 	name string
+	// group deduplicates concurrent loads
+	// of the same key.
+	group singleflight.Group
+	// local is an optional in-memory store checked
+	// before the main one (two-tier mode).
+	local *marshaler.Marshaler
+	// localExpiration limits local entries lifetime
+	// to bound staleness across replicas.
+	localExpiration time.Duration
+	// prefix is prepended to every key.
+	prefix string
+}
+
+// A cacheEntry wraps a cached value with its insertion time.
+type cacheEntry struct {
+	// Value is the original value passed to Put.
+	Value interface{} `msgpack:"value"`
+	// CreatedAt is the time the value was put into the cache.
+	CreatedAt time.Time `msgpack:"created_at"`
 }
 
 // Get retreives from a gocache provided store by key.
-// It returns the value, extraction time and the first error
+// It returns the value, insertion time and the first error
 // encountered while extracting the value by key.
 //
-// A successful Get returns value != nil, time.Now() and err == nil.
+// A successful Get returns value != nil, the time the value was
+// put into the cache and err == nil. Values stored without an insertion
+// time (i.e. written by older versions) return time.Now() instead.
+//
+// In two-tier mode the local store is checked first. Values found in
+// the main store populate the local one.
 func (c *CustomCache) Get(ctx context.Context, key string) (interface{}, time.Time, error) {
+	key = c.prefix + key
+	if c.local != nil {
+		if val, createdAt, err := get(ctx, c.local, key); err == nil {
+			return val, createdAt, nil
+		}
+	}
+
+	val, createdAt, err := get(ctx, c.store, key)
+	if err == nil && c.local != nil {
+		c.local.Set(ctx, key, cacheEntry{
+			Value:     val,
+			CreatedAt: createdAt,
+		}, store.WithExpiration(c.localExpiration))
+	}
+
+	return val, createdAt, err
+}
+
+// get fetches a raw value with a single store round-trip and decodes it
+// as a cacheEntry falling back to a legacy (unwrapped) value.
+func get(ctx context.Context, m *marshaler.Marshaler, key string) (interface{}, time.Time, error) {
+	var raw msgpack.RawMessage
+	if _, err := m.Get(ctx, key, &raw); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var entry cacheEntry
+	if err := msgpack.Unmarshal(raw, &entry); err == nil && !entry.CreatedAt.IsZero() {
+		return entry.Value, entry.CreatedAt, nil
+	}
+
 	var result interface{}
-	_, err := c.store.Get(ctx, key, &result)
-	return result, time.Now(), err
+	if err := msgpack.Unmarshal(raw, &result); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return result, time.Now(), nil
 }
 
 // Put stores into a gocache provided store by key, value and expiration date
+// along with the current time. In two-tier mode the value is written
+// through to both stores.
 // It returns the first error encountered while settings a new cache value.
 //
 // A successful Put returns err == nil.
 func (c *CustomCache) Put(ctx context.Context, key string, val interface{}, d time.Duration) error {
-	return c.store.Set(ctx, key, val, store.WithExpiration(d))
+	key = c.prefix + key
+	entry := cacheEntry{
+		Value:     val,
+		CreatedAt: time.Now(),
+	}
+
+	if err := c.store.Set(ctx, key, entry, store.WithExpiration(d)); err != nil {
+		return err
+	}
+
+	if c.local != nil {
+		expiration := c.localExpiration
+		if d > 0 && d < expiration {
+			expiration = d
+		}
+
+		return c.local.Set(ctx, key, entry, store.WithExpiration(expiration))
+	}
+
+	return nil
+}
+
+// GetOrSet retreives from a gocache provided store by key. On a miss
+// it calls loader, stores the result with expiration d and returns it.
+// Concurrent callers for the same missing key share a single loader call.
+// It returns the value and the first error encountered while loading
+// or storing the value.
+//
+// A successful GetOrSet returns value != nil and err == nil.
+func (c *CustomCache) GetOrSet(
+	ctx context.Context, key string, d time.Duration,
+	loader func() (interface{}, error),
+) (interface{}, error) {
+	if val, _, err := c.Get(ctx, key); err == nil {
+		return val, nil
+	}
+
+	val, err, _ := c.group.Do(key, func() (interface{}, error) {
+		if val, _, err := c.Get(ctx, key); err == nil {
+			return val, nil
+		}
+
+		val, err := loader()
+		if err != nil {
+			return nil, err
+		}
+
+		return val, c.Put(ctx, key, val, d)
+	})
+
+	return val, err
 }
 
-// Delete removes from a gocache provided store by key.
+// Delete removes from a gocache provided store by key. In two-tier
+// mode both stores are evicted.
 // It returns the first error encountered while removing a cache entry by key.
 //
 // A successful Delete returns err == nil.
 func (c *CustomCache) Delete(ctx context.Context, key string) error {
+	key = c.prefix + key
+	if c.local != nil {
+		// A missing local entry is not an error.
+		c.local.Delete(ctx, key)
+	}
+
 	return c.store.Delete(ctx, key)
 }
 
+// Ping checks the cache connectivity by looking up a probe key. A miss
+// means the store is reachable. In two-tier mode only the main store
+// is checked.
+// It returns the first error encountered while reaching the store.
+//
+// A successful Ping returns err == nil.
+func (c *CustomCache) Ping(ctx context.Context) error {
+	var raw msgpack.RawMessage
+	if _, err := c.store.Get(ctx, c.prefix+_pingKey, &raw); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 // String returns a gocache provided store name.
 func (c *CustomCache) String() string {
 	return c.name
@@ -81,26 +218,40 @@ func (c *CustomCache) String() string {
 //
 // Returns a go-micro cache compliant implementation based
 // on cache configuration. By default returns an in-memory
-// implementation
+// implementation. Keys are transparently prefixed with the
+// configured prefix.
 func NewCache(config *config.CacheConfig) cache.Cache {
+	var c *CustomCache
 	switch config.Cache.Type {
 	case 1:
-		return &CustomCache{
-			store: newMemory(config.Cache.Size),
+		c = &CustomCache{
+			store: newMemory(config.Cache.Size, config.Cache.Expiration),
 			name:  "Freecache",
 		}
 	case 2:
-		return &CustomCache{
-			store: newRedis(
-				config.Cache.Address, config.Cache.Username,
-				config.Cache.Password, config.Cache.Database,
-			),
-			name: "Redis",
+		c = &CustomCache{
+			store: newRedis(config),
+			name:  "Redis",
+		}
+	case 3:
+		c = &CustomCache{
+			store: newMemcache(config.Cache.Addresses),
+			name:  "Memcache",
+		}
+	case 4:
+		c = &CustomCache{
+			store:           newRedis(config),
+			name:            "Freecache+Redis",
+			local:           newMemory(config.Cache.Size, config.Cache.Expiration),
+			localExpiration: config.Cache.Expiration,
 		}
 	default:
-		return &CustomCache{
-			store: newMemory(config.Cache.Size),
+		c = &CustomCache{
+			store: newMemory(config.Cache.Size, config.Cache.Expiration),
 			name:  "Freecache",
 		}
 	}
+
+	c.prefix = config.Cache.Prefix
+	return c
 }
@@ -0,0 +1,263 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestMemoryCache() *CustomCache {
+	return &CustomCache{
+		store: newMemory(1, time.Minute),
+		name:  "Freecache",
+	}
+}
+
+func TestCacheGetReturnsPutTime(t *testing.T) {
+	c := newTestMemoryCache()
+	ctx := context.Background()
+
+	before := time.Now()
+	if err := c.Put(ctx, "key", "value", time.Minute); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+	after := time.Now()
+
+	time.Sleep(50 * time.Millisecond)
+	val, createdAt, err := c.Get(ctx, "key")
+	if err != nil || val != "value" {
+		t.Fatalf("expected a cached value, got %v, %v", val, err)
+	}
+
+	if createdAt.Before(before) || createdAt.After(after) {
+		t.Fatalf("expected the put time within [%v, %v], got %v", before, after, createdAt)
+	}
+}
+
+func TestCacheGetLegacyValue(t *testing.T) {
+	c := newTestMemoryCache()
+	ctx := context.Background()
+	if err := c.store.Set(ctx, "key", "legacy"); err != nil {
+		t.Fatalf("could not set a legacy value: %v", err)
+	}
+
+	if val, _, err := c.Get(ctx, "key"); err != nil || val != "legacy" {
+		t.Fatalf("expected a legacy value, got %v, %v", val, err)
+	}
+}
+
+func TestCachePutExpirationOutlivesDefault(t *testing.T) {
+	c := &CustomCache{
+		store: newMemory(1, time.Second),
+		name:  "Freecache",
+	}
+
+	ctx := context.Background()
+	if err := c.Put(ctx, "long", "value", 10*time.Second); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+
+	if err := c.store.Set(ctx, "default", cacheEntry{Value: "value", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("could not set a value: %v", err)
+	}
+
+	time.Sleep(2100 * time.Millisecond)
+	if _, _, err := c.Get(ctx, "default"); err == nil {
+		t.Fatal("expected the value to expire after the store default")
+	}
+
+	if val, _, err := c.Get(ctx, "long"); err != nil || val != "value" {
+		t.Fatalf("expected the per-call expiration to outlive the store default, got %v, %v", val, err)
+	}
+}
+
+func TestCacheGetMiss(t *testing.T) {
+	c := newTestMemoryCache()
+	if val, _, err := c.Get(context.Background(), "missing"); err == nil || !isNotFound(err) {
+		t.Fatalf("expected a miss, got %v, %v", val, err)
+	}
+}
+
+func TestCacheGetOrSetLoadsOnce(t *testing.T) {
+	c := newTestMemoryCache()
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func() (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan interface{}, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := c.GetOrSet(ctx, "key", time.Minute, loader)
+			if err != nil {
+				t.Errorf("could not get or set a value: %v", err)
+			}
+
+			results <- val
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected the loader to run once, got %d", n)
+	}
+
+	for val := range results {
+		if val != "value" {
+			t.Fatalf("expected a loaded value, got %v", val)
+		}
+	}
+}
+
+func newTestTwoTierCache(t *testing.T) (*CustomCache, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	var cacheConfig config.CacheConfig
+	cacheConfig.Cache.Type = 4
+	cacheConfig.Cache.Address = mr.Addr()
+	cacheConfig.Cache.Size = 1
+	cacheConfig.Cache.Expiration = time.Minute
+	return NewCache(&cacheConfig).(*CustomCache), mr
+}
+
+func TestTwoTierCacheLocalHit(t *testing.T) {
+	c, mr := newTestTwoTierCache(t)
+	ctx := context.Background()
+	if err := c.Put(ctx, "key", "value", time.Minute); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+
+	if !mr.Exists("key") {
+		t.Fatal("expected the value to be written through to redis")
+	}
+
+	mr.FlushAll()
+	if val, _, err := c.Get(ctx, "key"); err != nil || val != "value" {
+		t.Fatalf("expected a local hit without redis, got %v, %v", val, err)
+	}
+}
+
+func TestTwoTierCacheRemoteHitPopulatesLocal(t *testing.T) {
+	c, mr := newTestTwoTierCache(t)
+	ctx := context.Background()
+	if err := c.store.Set(ctx, "key", cacheEntry{Value: "value", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("could not set a redis value: %v", err)
+	}
+
+	if val, _, err := c.Get(ctx, "key"); err != nil || val != "value" {
+		t.Fatalf("expected a redis hit, got %v, %v", val, err)
+	}
+
+	mr.FlushAll()
+	if val, _, err := c.Get(ctx, "key"); err != nil || val != "value" {
+		t.Fatalf("expected the redis hit to populate the local store, got %v, %v", val, err)
+	}
+}
+
+func TestTwoTierCacheDelete(t *testing.T) {
+	c, mr := newTestTwoTierCache(t)
+	ctx := context.Background()
+	if err := c.Put(ctx, "key", "value", time.Minute); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+
+	if err := c.Delete(ctx, "key"); err != nil {
+		t.Fatalf("could not delete a value: %v", err)
+	}
+
+	if mr.Exists("key") {
+		t.Fatal("expected the redis entry to be evicted")
+	}
+
+	if _, _, err := get(ctx, c.local, "key"); err == nil {
+		t.Fatal("expected the local entry to be evicted")
+	}
+}
+
+func TestCachePrefixIsolation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	newPrefixedCache := func(prefix string) *CustomCache {
+		var cacheConfig config.CacheConfig
+		cacheConfig.Cache.Type = 2
+		cacheConfig.Cache.Address = mr.Addr()
+		cacheConfig.Cache.Prefix = prefix
+		return NewCache(&cacheConfig).(*CustomCache)
+	}
+
+	ctx := context.Background()
+	first, second := newPrefixedCache("first:"), newPrefixedCache("second:")
+	if err := first.Put(ctx, "key", "first", time.Minute); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+
+	if err := second.Put(ctx, "key", "second", time.Minute); err != nil {
+		t.Fatalf("could not put a value: %v", err)
+	}
+
+	if !mr.Exists("first:key") || !mr.Exists("second:key") || mr.Exists("key") {
+		t.Fatalf("expected prefixed keys in redis, got %v", mr.Keys())
+	}
+
+	if val, _, err := first.Get(ctx, "key"); err != nil || val != "first" {
+		t.Fatalf("expected the first cache value, got %v, %v", val, err)
+	}
+
+	if err := second.Delete(ctx, "key"); err != nil {
+		t.Fatalf("could not delete a value: %v", err)
+	}
+
+	if val, _, err := first.Get(ctx, "key"); err != nil || val != "first" {
+		t.Fatalf("expected the first cache value to survive, got %v, %v", val, err)
+	}
+}
+
+func TestCachePing(t *testing.T) {
+	mr := miniredis.RunT(t)
+	var cacheConfig config.CacheConfig
+	cacheConfig.Cache.Type = 2
+	cacheConfig.Cache.Address = mr.Addr()
+	c := NewCache(&cacheConfig).(*CustomCache)
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("expected a reachable cache, got %v", err)
+	}
+
+	mr.Close()
+	if err := c.Ping(context.Background()); err == nil {
+		t.Fatal("expected an unreachable cache to fail")
+	}
+}
@@ -0,0 +1,193 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cache provides caching adapters for go-micro
+//
+// The cache package should only be configured via yaml parameters or env variables.
+// Cache instance should be accessed via micro client.Client and used to manually store
+// and retreive cached values.
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/prometheus/client_golang/prometheus"
+	"go-micro.dev/v4/cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Total number of cache lookups by result (hit, miss, error). " +
+			"Hit rate is hit / (hit + miss).",
+	}, []string{"cache", "result"})
+	registerCacheMetrics sync.Once
+)
+
+// CacheStats contains cumulative cache lookup counters.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Errors uint64
+}
+
+// An InstrumentedCache wraps a go-micro cache to count hits, misses and
+// errors and optionally emit tracing spans. Counters are exposed via the
+// default prometheus registry.
+type InstrumentedCache struct {
+	inner    cache.Cache
+	tracer   trace.Tracer
+	hits     atomic.Uint64
+	misses   atomic.Uint64
+	failures atomic.Uint64
+}
+
+// NewInstrumentedCache wraps a cache with hit/miss/error counters.
+// Spans are emitted only when provider is not nil.
+func NewInstrumentedCache(inner cache.Cache, provider trace.TracerProvider) *InstrumentedCache {
+	registerCacheMetrics.Do(func() {
+		prometheus.MustRegister(cacheRequests)
+	})
+
+	c := &InstrumentedCache{inner: inner}
+	if provider != nil {
+		c.tracer = provider.Tracer("cache")
+	}
+
+	return c
+}
+
+// Get retreives a value by key and records a hit, miss or error.
+func (c *InstrumentedCache) Get(ctx context.Context, key string) (interface{}, time.Time, error) {
+	ctx, span := c.start(ctx, "Get", key)
+	val, created, err := c.inner.Get(ctx, key)
+
+	result := "hit"
+	switch {
+	case err == nil:
+		c.hits.Add(1)
+	case isNotFound(err):
+		result = "miss"
+		c.misses.Add(1)
+	default:
+		result = "error"
+		c.failures.Add(1)
+	}
+
+	cacheRequests.WithLabelValues(c.inner.String(), result).Inc()
+	if span != nil {
+		span.SetAttributes(attribute.String("cache.result", result))
+	}
+
+	// Misses are regular lookups and should not mark spans as failed.
+	spanErr := err
+	if result != "error" {
+		spanErr = nil
+	}
+
+	c.end(span, spanErr)
+	return val, created, err
+}
+
+// Put stores a value by key.
+func (c *InstrumentedCache) Put(ctx context.Context, key string, val interface{}, d time.Duration) error {
+	ctx, span := c.start(ctx, "Put", key)
+	err := c.inner.Put(ctx, key, val, d)
+	if err != nil {
+		c.failures.Add(1)
+	}
+
+	c.end(span, err)
+	return err
+}
+
+// Delete removes a value by key.
+func (c *InstrumentedCache) Delete(ctx context.Context, key string) error {
+	ctx, span := c.start(ctx, "Delete", key)
+	err := c.inner.Delete(ctx, key)
+	if err != nil {
+		c.failures.Add(1)
+	}
+
+	c.end(span, err)
+	return err
+}
+
+// Ping checks the wrapped cache connectivity. Caches without
+// a Ping method are considered reachable.
+func (c *InstrumentedCache) Ping(ctx context.Context) error {
+	if pinger, ok := c.inner.(interface{ Ping(context.Context) error }); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+// String returns the wrapped cache name.
+func (c *InstrumentedCache) String() string {
+	return c.inner.String()
+}
+
+// Stats returns cumulative hits, misses and errors.
+func (c *InstrumentedCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Errors: c.failures.Load(),
+	}
+}
+
+func (c *InstrumentedCache) start(ctx context.Context, method, key string) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	return c.tracer.Start(ctx, "cache."+method, trace.WithAttributes(
+		attribute.String("cache.name", c.inner.String()),
+		attribute.String("cache.key", key),
+	))
+}
+
+func (c *InstrumentedCache) end(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// isNotFound reports whether err is a gocache miss. Stores return
+// NotFound both by value and by pointer.
+func isNotFound(err error) bool {
+	var ptr *store.NotFound
+	var val store.NotFound
+	return errors.As(err, &ptr) || errors.As(err, &val)
+}
@@ -0,0 +1,41 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cache provides caching adapters for go-micro
+//
+// The cache package should only be configured via yaml parameters or env variables.
+// Cache instance should be accessed via micro client.Client and used to manually store
+// and retreive cached values.
+package cache
+
+import (
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/eko/gocache/lib/v4/cache"
+	"github.com/eko/gocache/lib/v4/marshaler"
+	memcache_store "github.com/eko/gocache/store/memcache/v4"
+)
+
+// newMemcache initializes a memcache gocache store
+// with memcached instances addresses.
+//
+// Returns a new memcache gocache compliant marshaler store
+func newMemcache(addresses []string) *marshaler.Marshaler {
+	memcacheStore := memcache_store.NewMemcache(memcache.New(addresses...))
+	cacheManager := cache.New[[]byte](memcacheStore)
+	return marshaler.New(cacheManager.GetCodec().GetStore())
+}
@@ -34,13 +34,14 @@ import (
 )
 
 // newMemory initializes an in-memory gocache store
-// with the buffer size provided.
+// with the buffer size and default expiration provided.
+// Per-call expiration passed to Set overrides the default one.
 //
 // Returns a new in-memory gocache compliant marshaler store
-func newMemory(size int) *marshaler.Marshaler {
+func newMemory(size int, expiration time.Duration) *marshaler.Marshaler {
 	freecacheStore := freecache_store.NewFreecache(
 		freecache.NewCache(size*1024*1024),
-		store.WithExpiration(10*time.Second),
+		store.WithExpiration(expiration),
 	)
 	cacheManage := cache.New[[]byte](freecacheStore)
 	return marshaler.New(cacheManage.GetCodec().GetStore())
@@ -24,6 +24,9 @@
 package cache
 
 import (
+	"crypto/tls"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/eko/gocache/lib/v4/cache"
 	"github.com/eko/gocache/lib/v4/marshaler"
 	redis_store "github.com/eko/gocache/store/redis/v4"
@@ -31,19 +34,59 @@ import (
 )
 
 // newRedis initializes a redis gocache store
-// with redis address, username, password and database
-// credentials to establish a database connection
+// with redis address, username, password, database
+// credentials and connection options to establish
+// a database connection. Uses a sentinel backed failover
+// client when a master name is configured
 //
 // Returns a new redis gocache compliant marshaler store
-func newRedis(address, username, password string, db int) *marshaler.Marshaler {
-	redisClient := redis.NewClient(&redis.Options{
-		Username: username,
-		Addr:     address,
-		Password: password,
-		DB:       db,
-	})
+func newRedis(config *config.CacheConfig) *marshaler.Marshaler {
+	var redisClient *redis.Client
+	if config.Cache.MasterName != "" {
+		redisClient = newRedisFailoverClient(config)
+	} else {
+		redisClient = newRedisClient(config)
+	}
+
 	redisStore := redis_store.NewRedis(redisClient)
 	cacheManager := cache.New[string](redisStore)
 	marshaller := marshaler.New(cacheManager.GetCodec().GetStore())
 	return marshaller
 }
+
+// newRedisClient initializes a single node redis client.
+func newRedisClient(config *config.CacheConfig) *redis.Client {
+	opts := &redis.Options{
+		Username:     config.Cache.Username,
+		Addr:         config.Cache.Address,
+		Password:     config.Cache.Password,
+		DB:           config.Cache.Database,
+		PoolSize:     config.Cache.PoolSize,
+		MinIdleConns: config.Cache.MinIdleConns,
+	}
+
+	if config.Cache.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return redis.NewClient(opts)
+}
+
+// newRedisFailoverClient initializes a sentinel backed redis client.
+func newRedisFailoverClient(config *config.CacheConfig) *redis.Client {
+	opts := &redis.FailoverOptions{
+		MasterName:    config.Cache.MasterName,
+		SentinelAddrs: config.Cache.SentinelAddresses,
+		Username:      config.Cache.Username,
+		Password:      config.Cache.Password,
+		DB:            config.Cache.Database,
+		PoolSize:      config.Cache.PoolSize,
+		MinIdleConns:  config.Cache.MinIdleConns,
+	}
+
+	if config.Cache.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return redis.NewFailoverClient(opts)
+}
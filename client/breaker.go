@@ -0,0 +1,91 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cache auto-injects and initializes a go-micro client for go-micro services
+//
+// The client package extracts from fx available registry and broker
+// implementations initialized via yaml parameters or env variables.
+// Client instance may be injected into any structure following fx injecton
+// guidelines.
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/afex/hystrix-go/hystrix"
+	"go-micro.dev/v4/client"
+)
+
+// circuitBreakerWrapper wraps outgoing calls in hystrix commands
+// named after the target service and endpoint.
+type circuitBreakerWrapper struct {
+	client.Client
+	config     hystrix.CommandConfig
+	configured sync.Map
+}
+
+// newCircuitBreakerWrapper builds a hystrix client wrapper from
+// circuit breaker configuration. Zero values fall back to hystrix defaults.
+// The command timeout is raised to the request timeout when lower, since
+// the request timeout is a single deadline covering every retry and the
+// breaker would otherwise cut calls short.
+func newCircuitBreakerWrapper(breaker config.CircuitBreakerConfig, requestTimeout time.Duration) client.Wrapper {
+	timeout := breaker.Timeout
+	if ms := int(requestTimeout.Milliseconds()); ms > timeout {
+		timeout = ms
+	}
+
+	return func(c client.Client) client.Client {
+		return &circuitBreakerWrapper{
+			Client: c,
+			config: hystrix.CommandConfig{
+				Timeout:                timeout,
+				MaxConcurrentRequests:  breaker.MaxConcurrent,
+				RequestVolumeThreshold: breaker.VolumeThreshold,
+				SleepWindow:            breaker.SleepWindow,
+				ErrorPercentThreshold:  breaker.ErrorPercentThreshold,
+			},
+		}
+	}
+}
+
+// Call runs a go-micro call within a hystrix command.
+// Returns ErrCircuitOpen when the circuit is open.
+func (c *circuitBreakerWrapper) Call(
+	ctx context.Context, req client.Request,
+	rsp interface{}, opts ...client.CallOption,
+) error {
+	name := req.Service() + "." + req.Endpoint()
+	if _, loaded := c.configured.LoadOrStore(name, struct{}{}); !loaded {
+		hystrix.ConfigureCommand(name, c.config)
+	}
+
+	err := hystrix.DoC(ctx, name, func(ctx context.Context) error {
+		return c.Client.Call(ctx, req, rsp, opts...)
+	}, nil)
+
+	if errors.Is(err, hystrix.ErrCircuitOpen) {
+		return ErrCircuitOpen
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/client"
+)
+
+type failingClient struct {
+	client.Client
+	calls int
+}
+
+func (c *failingClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	c.calls++
+	return errors.New("service unavailable")
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	inner := &failingClient{}
+	c := newCircuitBreakerWrapper(config.CircuitBreakerConfig{
+		Timeout:               1000,
+		MaxConcurrent:         10,
+		VolumeThreshold:       3,
+		SleepWindow:           60000,
+		ErrorPercentThreshold: 50,
+	}, 0)(inner)
+
+	req := client.NewRequest("breaker.test", "Service.Open", nil)
+	var err error
+	for i := 0; i < 50 && !errors.Is(err, ErrCircuitOpen); i++ {
+		err = c.Call(context.Background(), req, nil)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to open, got %v", err)
+	}
+
+	calls := inner.calls
+	if err := c.Call(context.Background(), req, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected calls to be rejected while the circuit is open, got %v", err)
+	}
+
+	if inner.calls != calls {
+		t.Fatal("expected an open circuit not to reach the service")
+	}
+}
+
+func TestCircuitBreakerTimeoutCoversRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		timeout        int
+		requestTimeout time.Duration
+		expected       int
+	}{
+		{name: "raised to the request timeout", timeout: 5000, requestTimeout: 30 * time.Second, expected: 30000},
+		{name: "longer breaker timeout", timeout: 60000, requestTimeout: 30 * time.Second, expected: 60000},
+		{name: "no request timeout", timeout: 5000, expected: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCircuitBreakerWrapper(config.CircuitBreakerConfig{Timeout: tt.timeout}, tt.requestTimeout)(&failingClient{})
+			if timeout := c.(*circuitBreakerWrapper).config.Timeout; timeout != tt.expected {
+				t.Fatalf("expected a %dms breaker timeout, got %dms", tt.expected, timeout)
+			}
+		})
+	}
+}
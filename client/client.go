@@ -25,6 +25,7 @@
 package client
 
 import (
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
 	"go-micro.dev/v4/client"
 	"go-micro.dev/v4/registry"
@@ -35,12 +36,27 @@ import (
 //
 // Returns a go-micro compliant client implementation based
 // on registry and broker configuration. By default uses in-memory
-// registry and broker implementations
+// registry and broker implementations. Outgoing calls are wrapped
+// in a circuit breaker configured via resilience configuration.
+// Failed calls are retried with exponential backoff within the circuit
+// breaker command and the request deadline. Calls time out after
+// the configured request timeout unless overridden per call. The request
+// timeout covers every retry, so the circuit breaker timeout is raised to it
+// when lower, so by default calls get 30s rather than the 5000ms breaker default.
+// Per call timeouts above it are still cut by the circuit breaker
 func NewClient(
 	registry registry.Registry, broker messaging.BrokerWithOptions,
+	clientConfig *config.ClientConfig, resilienceConfig *config.ResilienceConfig,
 ) client.Client {
 	return client.NewClient(
 		client.Registry(registry),
 		client.Broker(broker.Broker),
+		client.RequestTimeout(clientConfig.Client.RequestTimeout),
+		client.Retries(clientConfig.Client.Retries),
+		client.Retry(retryWithinDeadline),
+		client.Backoff(newExponentialBackoff(clientConfig.Client.RetryBackoff)),
+		client.Wrap(newCircuitBreakerWrapper(
+			resilienceConfig.Resilience.CircuitBreaker, clientConfig.Client.RequestTimeout,
+		)),
 	)
 }
@@ -0,0 +1,97 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"go-micro.dev/v4/broker"
+	"go-micro.dev/v4/client"
+	"go-micro.dev/v4/errors"
+	"go-micro.dev/v4/registry"
+	"go-micro.dev/v4/server"
+)
+
+type SlowRequest struct {
+	Delay time.Duration `json:"delay"`
+}
+
+type SlowResponse struct {
+	Done bool `json:"done"`
+}
+
+// Slow is a test service. Its method types are exported as go-micro requires.
+type Slow struct{}
+
+func (s *Slow) Sleep(ctx context.Context, req *SlowRequest, rsp *SlowResponse) error {
+	time.Sleep(req.Delay)
+	rsp.Done = true
+	return nil
+}
+
+func newTestSlowClient(t *testing.T, timeout time.Duration) client.Client {
+	reg := registry.NewMemoryRegistry()
+	srv := server.NewServer(
+		server.Name("slow"),
+		server.Address("127.0.0.1:0"),
+		server.Registry(reg),
+	)
+
+	if err := srv.Handle(srv.NewHandler(&Slow{})); err != nil {
+		t.Fatalf("could not register a slow handler: %s", err.Error())
+	}
+
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start a slow service: %s", err.Error())
+	}
+
+	t.Cleanup(func() { srv.Stop() })
+
+	var clientConfig config.ClientConfig
+	clientConfig.Client.RequestTimeout = timeout
+
+	var resilienceConfig config.ResilienceConfig
+	resilienceConfig.Resilience.CircuitBreaker.Timeout = 5000
+
+	return NewClient(reg, messaging.BrokerWithOptions{
+		Broker: broker.NewMemoryBroker(),
+	}, &clientConfig, &resilienceConfig)
+}
+
+func TestClientRequestTimeout(t *testing.T) {
+	c := newTestSlowClient(t, 100*time.Millisecond)
+	req := c.NewRequest("slow", "Slow.Sleep", &SlowRequest{Delay: 500 * time.Millisecond})
+
+	var rsp SlowResponse
+	err := c.Call(context.Background(), req, &rsp)
+	if merr := errors.FromError(err); merr == nil || merr.Code != 408 {
+		t.Fatalf("expected a request timeout error, got %v", err)
+	}
+
+	if err := c.Call(
+		context.Background(), req, &rsp,
+		client.WithRequestTimeout(2*time.Second),
+	); err != nil || !rsp.Done {
+		t.Fatalf("expected a per call timeout to override the default one, got %v", err)
+	}
+}
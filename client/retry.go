@@ -0,0 +1,67 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package cache auto-injects and initializes a go-micro client for go-micro services
+//
+// The client package extracts from fx available registry and broker
+// implementations initialized via yaml parameters or env variables.
+// Client instance may be injected into any structure following fx injecton
+// guidelines.
+package client
+
+import (
+	"context"
+	"time"
+
+	"go-micro.dev/v4/client"
+)
+
+// _maxBackoff caps exponential backoff delays.
+const _maxBackoff = 30 * time.Second
+
+// newExponentialBackoff builds a backoff doubling the base delay on
+// every attempt. Returns the context error when the delay would exceed
+// the request deadline.
+func newExponentialBackoff(base time.Duration) client.BackoffFunc {
+	return func(ctx context.Context, req client.Request, attempts int) (time.Duration, error) {
+		if attempts == 0 || base <= 0 {
+			return 0, nil
+		}
+
+		delay := base << (attempts - 1)
+		if delay <= 0 || delay > _maxBackoff {
+			delay = _maxBackoff
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return 0, context.DeadlineExceeded
+		}
+
+		return delay, nil
+	}
+}
+
+// retryWithinDeadline retries failed calls unless the request
+// context is done.
+func retryWithinDeadline(ctx context.Context, req client.Request, retryCount int, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, nil
+	}
+
+	return client.RetryOnError(ctx, req, retryCount, err)
+}
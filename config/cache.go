@@ -25,11 +25,9 @@ package config
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A CacheConfig provides go-micro cache configuration for
@@ -41,19 +39,35 @@ type CacheConfig struct {
 		// Type is gocache adapter type to be auto-configured.
 		// 1 - Freecache.
 		// 2 - Redis.
+		// 3 - Memcache.
+		// 4 - Two-tier Freecache (L1) + Redis (L2). Local entries live
+		// no longer than Expiration.
 		//
 		// By default - 1
 		Type int `yaml:"type" env:"CACHE_TYPE,overwrite"`
+		// Prefix is an optional namespace prepended to every cache key.
+		// Used to avoid collisions between services sharing a store.
+		//
+		// By default - no prefix
+		Prefix string `yaml:"prefix" env:"CACHE_PREFIX,overwrite"`
 		// Size is an optional field used to manually cache freecache
 		// buffer size.
 		//
 		// By default - 10 * 1024 * 1024
 		Size int `yaml:"size" env:"CACHE_SIZE,overwrite"`
+		// Expiration is an optional field used to set freecache default
+		// entries expiration. Expiration passed to Put takes precedence.
+		//
+		// By default - 10s
+		Expiration time.Duration `yaml:"expiration" env:"CACHE_EXPIRATION,overwrite"`
 		// Address is an optional field used to manually change redis
 		// instance address.
 		//
 		// By default - 0.0.0.0:6379
 		Address string `yaml:"address" env:"CACHE_ADDRESS,overwrite"`
+		// Addresses is a list of memcached instances addresses. Required
+		// for memcache type.
+		Addresses []string `yaml:"addresses" env:"CACHE_ADDRESSES,overwrite"`
 		// Username is an optional field used to manually change redis
 		// instance username
 		//
@@ -66,6 +80,28 @@ type CacheConfig struct {
 		Password string `yaml:"password" env:"CACHE_PASSWORD,overwrite"`
 		//
 		Database int `yaml:"database" env:"CACHE_DATABASE,overwrite"`
+		// TLS is an optional field used to enable TLS connections to
+		// redis instance.
+		//
+		// By default - false
+		TLS bool `yaml:"tls" env:"CACHE_TLS,overwrite"`
+		// PoolSize is an optional field used to limit redis connection pool
+		// size.
+		//
+		// By default - 10 connections per CPU
+		PoolSize int `yaml:"pool_size" env:"CACHE_POOL_SIZE,overwrite"`
+		// MinIdleConns is an optional field used to keep a minimum number of
+		// idle redis connections.
+		//
+		// By default - 0
+		MinIdleConns int `yaml:"min_idle_conns" env:"CACHE_MIN_IDLE_CONNS,overwrite"`
+		// MasterName is an optional field used to enable redis sentinel
+		// failover. Requires at least one sentinel address.
+		//
+		// By default - no sentinel
+		MasterName string `yaml:"master_name" env:"CACHE_MASTER_NAME,overwrite"`
+		// SentinelAddresses is a list of redis sentinel instances addresses.
+		SentinelAddresses []string `yaml:"sentinel_addresses" env:"CACHE_SENTINEL_ADDRESSES,overwrite"`
 	} `yaml:"cache"`
 }
 
@@ -76,7 +112,17 @@ type CacheConfig struct {
 // cause application to panic
 func (b *CacheConfig) Validate() error {
 	switch b.Cache.Type {
-	case 2:
+	case 2, 4:
+		if b.Cache.MasterName != "" {
+			if len(b.Cache.SentinelAddresses) == 0 {
+				return &InvalidConfigurationParameterError{
+					Parameter: "SentinelAddresses",
+					Reason:    "Redis sentinel cache must have at least one sentinel address",
+				}
+			}
+			return nil
+		}
+
 		if b.Cache.Address == "" {
 			return &InvalidConfigurationParameterError{
 				Parameter: "Address",
@@ -84,6 +130,14 @@ func (b *CacheConfig) Validate() error {
 			}
 		}
 		return nil
+	case 3:
+		if len(b.Cache.Addresses) == 0 {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Addresses",
+				Reason:    "Memcache must have at least one valid address",
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -98,18 +152,15 @@ func BuildNewCacheConfig(path string) func() (*CacheConfig, error) {
 	return func() (*CacheConfig, error) {
 		var config CacheConfig
 		config.Cache.Size = 10
+		config.Cache.Expiration = 10 * time.Second
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -118,6 +169,10 @@ func BuildNewCacheConfig(path string) func() (*CacheConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -0,0 +1,120 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package config provides go-micro adapters' configuration structures
+//
+// The config package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/sethvargo/go-envconfig"
+)
+
+// A ClientConfig provides go-micro client configuration.
+// This structure is expected to be initialized automatically by fx via yaml and env.
+type ClientConfig struct {
+	// Client is a nested structure used as a marker for yaml configuration.
+	Client struct {
+		// Retries is a number of retries of failed calls. Retries happen
+		// within a single circuit breaker command and request deadline.
+		//
+		// By default - 1
+		Retries int `yaml:"retries" env:"CLIENT_RETRIES,overwrite"`
+		// RetryBackoff is a base delay between retries. The delay doubles
+		// on every attempt.
+		//
+		// By default - 100ms
+		RetryBackoff time.Duration `yaml:"retry_backoff" env:"CLIENT_RETRY_BACKOFF,overwrite"`
+		// RequestTimeout is a default call timeout covering every retry. May be
+		// overridden per call via client.WithRequestTimeout. The client's circuit
+		// breaker timeout is raised to it when lower.
+		//
+		// By default - 30s
+		RequestTimeout time.Duration `yaml:"request_timeout" env:"CLIENT_REQUEST_TIMEOUT,overwrite"`
+	} `yaml:"client"`
+}
+
+// Validate is called by fx and bootstrapper automatically after config initialization.
+// It returns the first error encountered during validation.
+//
+// A successful Validate returns err == nil. Errors other than nil will
+// cause application to panic
+func (cc *ClientConfig) Validate() error {
+	if cc.Client.Retries < 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Retries",
+			Reason:    "Should not be negative",
+		}
+	}
+
+	if cc.Client.RetryBackoff < 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "RetryBackoff",
+			Reason:    "Should not be negative",
+		}
+	}
+
+	if cc.Client.RequestTimeout <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "RequestTimeout",
+			Reason:    "Should be positive",
+		}
+	}
+
+	return nil
+}
+
+// A ClientConfig constructor. Called automatically by fx and
+// bootstrapper with config path provided via cli.
+//
+// Returns a client configuration used to initialize a go-micro client
+// and the first encountered error.
+func BuildNewClientConfig(path string) func() (*ClientConfig, error) {
+	return func() (*ClientConfig, error) {
+		var config ClientConfig
+		config.Client.Retries = 1
+		config.Client.RetryBackoff = 100 * time.Millisecond
+		config.Client.RequestTimeout = 30 * time.Second
+		if path != "" {
+			if err := decodeConfig(path, &config); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
@@ -25,11 +25,11 @@ package config
 
 import (
 	"context"
-	"os"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A CORSConfig provides configuration for cors middleware passed to a go-micro service.
@@ -62,9 +62,49 @@ type CORSConfig struct {
 // A successful Validate returns err == nil. Errors other than nil will
 // cause application to panic
 func (cc *CORSConfig) Validate() error {
+	cc.CORS.AllowedOrigins = normalizeCORSValues(cc.CORS.AllowedOrigins, func(origin string) string {
+		return strings.TrimSuffix(strings.ToLower(origin), "/")
+	})
+	cc.CORS.AllowedMethods = normalizeCORSValues(cc.CORS.AllowedMethods, strings.ToUpper)
+	cc.CORS.AllowedHeaders = normalizeCORSValues(cc.CORS.AllowedHeaders, http.CanonicalHeaderKey)
+
+	if cc.CORS.AllowCredentials {
+		for _, origin := range cc.CORS.AllowedOrigins {
+			if origin == "*" {
+				return &InvalidConfigurationParameterError{
+					Parameter: "AllowedOrigins",
+					Reason:    "Wildcard origin can't be combined with credentials",
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
+// normalizeCORSValues trims and normalizes values, dropping empty ones and
+// duplicates while preserving the original order.
+func normalizeCORSValues(values []string, normalize func(string) string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		value = normalize(value)
+		if _, ok := seen[value]; ok {
+			continue
+		}
+
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+
+	return result
+}
+
 // A CORSConfig constructor. Called automatically by fx and
 // bootstrapper with config path provided via cli.
 //
@@ -77,17 +117,13 @@ func BuildNewCorsConfig(path string) func() (*CORSConfig, error) {
 		config.CORS.AllowedMethods = []string{"*"}
 		config.CORS.AllowedHeaders = []string{"*"}
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -96,6 +132,10 @@ func BuildNewCorsConfig(path string) func() (*CORSConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -0,0 +1,58 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCORSConfigCredentialsWildcard(t *testing.T) {
+	var config CORSConfig
+	config.CORS.AllowedOrigins = []string{" * "}
+	config.CORS.AllowCredentials = true
+	assertInvalidParameter(t, config.Validate(), "AllowedOrigins")
+
+	config.CORS.AllowedOrigins = []string{"*"}
+	config.CORS.AllowCredentials = false
+	assertInvalidParameter(t, config.Validate(), "")
+}
+
+func TestCORSConfigNormalize(t *testing.T) {
+	var config CORSConfig
+	config.CORS.AllowedOrigins = []string{" https://Example.com/ ", "https://example.com", "", "https://onlyoffice.com"}
+	config.CORS.AllowedMethods = []string{"get", "GET", " post "}
+	config.CORS.AllowedHeaders = []string{"content-type", "Content-Type", "x-request-id"}
+	config.CORS.AllowCredentials = true
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err.Error())
+	}
+
+	if expected := []string{"https://example.com", "https://onlyoffice.com"}; !reflect.DeepEqual(config.CORS.AllowedOrigins, expected) {
+		t.Fatalf("expected origins %v, got %v", expected, config.CORS.AllowedOrigins)
+	}
+
+	if expected := []string{"GET", "POST"}; !reflect.DeepEqual(config.CORS.AllowedMethods, expected) {
+		t.Fatalf("expected methods %v, got %v", expected, config.CORS.AllowedMethods)
+	}
+
+	if expected := []string{"Content-Type", "X-Request-Id"}; !reflect.DeepEqual(config.CORS.AllowedHeaders, expected) {
+		t.Fatalf("expected headers %v, got %v", expected, config.CORS.AllowedHeaders)
+	}
+}
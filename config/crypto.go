@@ -25,11 +25,9 @@ package config
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A CryptoConfig provides configuration for
@@ -39,23 +37,98 @@ type CryptoConfig struct {
 	// Crypto is a nested structure used as a marker for yaml configurations
 	Crypto struct {
 		// EncryptorType is an encryption algorithm type.
-		// 1 - AES Gcm
+		// 1 - AES Gcm (16, 24 or 32 bytes keys)
+		// 2 - ChaCha20-Poly1305 (32 bytes keys only)
+		//
+		// Switching from AES to ChaCha20-Poly1305 requires 32 bytes keys.
 		//
 		// By default - 1
 		EncryptorType int `yaml:"encryptor_type" env:"ENCRYPTOR_TYPE"`
+		// AesLegacyKeys enables AES decryption of ciphertexts produced
+		// before key validation with keys zero-padded or truncated to 32
+		// bytes. Should be disabled once such data is re-encrypted.
+		//
+		// By default - false
+		AesLegacyKeys bool `yaml:"aes_legacy_keys" env:"AES_LEGACY_KEYS"`
 		// JwtManagerType is a JWT library implementation type.
 		// 1 - go-jwt/v5
+		// 2 - go-jwt/v5 RS256/ES256 (PEM encoded keys)
 		//
 		// By default - 1
 		JwtManagerType int `yaml:"jwt_manager_type" env:"JWT_MANAGER_TYPE"`
+		// StateGeneratorType is an oauth2 state generator implementation type.
+		// 1 - HMAC-SHA256 (shared secret)
+		// 2 - Ed25519 (PEM encoded private key to generate, public key to verify)
+		//
+		// By default - 1
+		StateGeneratorType int `yaml:"state_generator_type" env:"STATE_GENERATOR_TYPE"`
+		// JwtHeader is a custom request header carrying a jwt. Checked by
+		// VerifyRequest after the Authorization header.
+		//
+		// By default - AuthorizationJwt
+		JwtHeader string `yaml:"jwt_header" env:"JWT_HEADER"`
 		// HasherType is a hash function implementation type.
 		// 1 - md5
+		// 2 - bcrypt
+		// 3 - sha256
+		// 4 - argon2id
+		// 5 - HMAC-SHA256 (crypto.KeyedHasher). Unkeyed hashing
+		// falls back to md5.
 		//
 		// By default - 1
 		HasherType int `yaml:"hasher_type" env:"HASHER_TYPE"`
+		// Argon2Time is argon2id number of iterations.
+		//
+		// By default - 1
+		Argon2Time uint32 `yaml:"argon2_time" env:"ARGON2_TIME"`
+		// Argon2Memory is argon2id memory cost in KiB.
+		//
+		// By default - 64 * 1024
+		Argon2Memory uint32 `yaml:"argon2_memory" env:"ARGON2_MEMORY"`
+		// Argon2Parallelism is argon2id number of threads.
+		//
+		// By default - 4
+		Argon2Parallelism uint8 `yaml:"argon2_parallelism" env:"ARGON2_PARALLELISM"`
 	} `yaml:"crypto"`
 }
 
+// Validate is called by fx and bootstrapper automatically after config initialization.
+// It returns the first error encountered during validation.
+//
+// A successful Validate returns err == nil. Errors other than nil will
+// cause application to panic
+func (c *CryptoConfig) Validate() error {
+	if c.Crypto.EncryptorType < 0 || c.Crypto.EncryptorType > 2 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "EncryptorType",
+			Reason:    "Unsupported encryptor type",
+		}
+	}
+
+	if c.Crypto.JwtManagerType < 0 || c.Crypto.JwtManagerType > 2 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "JwtManagerType",
+			Reason:    "Unsupported jwt manager type",
+		}
+	}
+
+	if c.Crypto.StateGeneratorType < 0 || c.Crypto.StateGeneratorType > 2 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "StateGeneratorType",
+			Reason:    "Unsupported state generator type",
+		}
+	}
+
+	if c.Crypto.HasherType < 0 || c.Crypto.HasherType > 5 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "HasherType",
+			Reason:    "Unsupported hasher type",
+		}
+	}
+
+	return nil
+}
+
 // A CryptoConfig constructor. Called automatically by fx and
 // bootstrapper with config path provided via cli.
 //
@@ -64,18 +137,18 @@ type CryptoConfig struct {
 func BuildNewCryptoConfig(path string) func() (*CryptoConfig, error) {
 	return func() (*CryptoConfig, error) {
 		var config CryptoConfig
+		config.Crypto.Argon2Time = 1
+		config.Crypto.Argon2Memory = 64 * 1024
+		config.Crypto.Argon2Parallelism = 4
+		config.Crypto.JwtHeader = "AuthorizationJwt"
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -84,6 +157,10 @@ func BuildNewCryptoConfig(path string) func() (*CryptoConfig, error) {
 			return nil, err
 		}
 
-		return &config, nil
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
 	}
 }
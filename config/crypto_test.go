@@ -0,0 +1,47 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import "testing"
+
+func TestCryptoConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(c *CryptoConfig)
+		parameter string
+	}{
+		{"defaults", func(c *CryptoConfig) {}, ""},
+		{"negative encryptor type", func(c *CryptoConfig) { c.Crypto.EncryptorType = -1 }, "EncryptorType"},
+		{"unknown encryptor type", func(c *CryptoConfig) { c.Crypto.EncryptorType = 3 }, "EncryptorType"},
+		{"negative jwt manager type", func(c *CryptoConfig) { c.Crypto.JwtManagerType = -1 }, "JwtManagerType"},
+		{"unknown jwt manager type", func(c *CryptoConfig) { c.Crypto.JwtManagerType = 3 }, "JwtManagerType"},
+		{"negative state generator type", func(c *CryptoConfig) { c.Crypto.StateGeneratorType = -1 }, "StateGeneratorType"},
+		{"unknown state generator type", func(c *CryptoConfig) { c.Crypto.StateGeneratorType = 3 }, "StateGeneratorType"},
+		{"negative hasher type", func(c *CryptoConfig) { c.Crypto.HasherType = -1 }, "HasherType"},
+		{"unknown hasher type", func(c *CryptoConfig) { c.Crypto.HasherType = 6 }, "HasherType"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config CryptoConfig
+			tt.modify(&config)
+			assertInvalidParameter(t, config.Validate(), tt.parameter)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package config provides go-micro adapters' configuration structures
+//
+// The config package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// decodeConfig decodes a comma-separated list of configuration files and
+// directories into config. Later files override earlier ones. Nested
+// structures are merged field by field, lists are replaced. Directory
+// files with supported extensions are decoded in lexical order.
+func decodeConfig(path string, config any) error {
+	for _, p := range strings.Split(path, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			if err := decodeFile(p, config); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return err
+		}
+
+		var files []string
+		for _, entry := range entries {
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json", ".toml":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(p, entry.Name()))
+				}
+			}
+		}
+
+		sort.Strings(files)
+		for _, file := range files {
+			if err := decodeFile(file, config); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeFile decodes a configuration file into config. The format is
+// detected by file extension (.yaml/.yml, .json, .toml). Extensionless and
+// unknown paths are decoded as yaml.
+//
+// JSON and TOML documents are converted to yaml so that the same yaml
+// field tags apply to every format.
+func decodeFile(path string, config any) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(file)
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return err
+		}
+	case ".toml":
+		if _, err := toml.NewDecoder(file).Decode(&doc); err != nil {
+			return err
+		}
+	default:
+		return yaml.NewDecoder(file).Decode(config)
+	}
+
+	buf, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(buf, config)
+}
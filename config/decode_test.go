@@ -0,0 +1,106 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write a config file: %s", err.Error())
+	}
+
+	return path
+}
+
+const (
+	_testBaseConfig = `
+logger:
+  name: base
+  level: 2
+  pretty: true
+  elastic:
+    level: 3
+`
+	_testOverrideConfig = `
+logger:
+  level: 3
+  elastic:
+    address: http://localhost:9200
+`
+)
+
+func assertLayeredLoggerConfig(t *testing.T, config *LoggerConfig, level int) {
+	if config.Logger.Name != "base" || !config.Logger.Pretty {
+		t.Fatalf("expected base values to be kept, got %+v", config.Logger)
+	}
+
+	if config.Logger.Level != level {
+		t.Fatalf("expected level %d, got %d", level, config.Logger.Level)
+	}
+
+	if config.Logger.Elastic.Address != "http://localhost:9200" || config.Logger.Elastic.Level != 3 {
+		t.Fatalf("expected nested values to be merged, got %+v", config.Logger.Elastic)
+	}
+}
+
+func TestDecodeConfigPathList(t *testing.T) {
+	dir := t.TempDir()
+	base := writeTestConfig(t, dir, "base.yaml", _testBaseConfig)
+	override := writeTestConfig(t, dir, "override.yaml", _testOverrideConfig)
+
+	config, err := BuildNewLoggerConfig(base + "," + override)()
+	if err != nil {
+		t.Fatalf("could not build a layered config: %s", err.Error())
+	}
+
+	assertLayeredLoggerConfig(t, config, 3)
+}
+
+func TestDecodeConfigDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestConfig(t, dir, "20-override.yml", _testOverrideConfig)
+	writeTestConfig(t, dir, "10-base.yaml", _testBaseConfig)
+	writeTestConfig(t, dir, "README.md", "logger: [")
+
+	config, err := BuildNewLoggerConfig(dir)()
+	if err != nil {
+		t.Fatalf("could not build a config from a directory: %s", err.Error())
+	}
+
+	assertLayeredLoggerConfig(t, config, 3)
+}
+
+func TestDecodeConfigEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	base := writeTestConfig(t, dir, "base.yaml", _testBaseConfig)
+	override := writeTestConfig(t, dir, "override.yaml", _testOverrideConfig)
+	t.Setenv("LOGGER_LEVEL", "5")
+
+	config, err := BuildNewLoggerConfig(base + "," + override)()
+	if err != nil {
+		t.Fatalf("could not build a layered config: %s", err.Error())
+	}
+
+	assertLayeredLoggerConfig(t, config, 5)
+}
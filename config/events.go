@@ -0,0 +1,109 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package config provides go-micro adapters' configuration structures
+//
+// The config package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/sethvargo/go-envconfig"
+)
+
+// An EventsConfig provides event emitter configuration.
+// This structure is expected to be initialized automatically by fx via yaml and env.
+type EventsConfig struct {
+	// Events is a nested structure used as a marker for yaml configuration.
+	Events struct {
+		// Async is a flag to run listeners on a background worker pool.
+		//
+		// By default - false
+		Async bool `yaml:"async" env:"EVENTS_ASYNC,overwrite"`
+		// Workers is a number of background workers used in async mode.
+		//
+		// By default - 4
+		Workers int `yaml:"workers" env:"EVENTS_WORKERS,overwrite"`
+		// QueueSize is a number of events queued in async mode before
+		// Fire blocks.
+		//
+		// By default - 1024
+		QueueSize int `yaml:"queue_size" env:"EVENTS_QUEUE_SIZE,overwrite"`
+	} `yaml:"events"`
+}
+
+// Validate is called by fx and bootstrapper automatically after config initialization.
+// It returns the first error encountered during validation.
+//
+// A successful Validate returns err == nil. Errors other than nil will
+// cause application to panic
+func (ec *EventsConfig) Validate() error {
+	if ec.Events.Async && ec.Events.Workers <= 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Workers",
+			Reason:    "Async events must have at least one worker",
+		}
+	}
+
+	if ec.Events.Async && ec.Events.QueueSize < 0 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "QueueSize",
+			Reason:    "Should not be negative",
+		}
+	}
+
+	return nil
+}
+
+// An EventsConfig constructor. Called automatically by fx and
+// bootstrapper with config path provided via cli.
+//
+// Returns an events configuration used to initialize an emitter
+// and the first encountered error.
+func BuildNewEventsConfig(path string) func() (*EventsConfig, error) {
+	return func() (*EventsConfig, error) {
+		var config EventsConfig
+		config.Events.Workers = 4
+		config.Events.QueueSize = 1024
+		if path != "" {
+			if err := decodeConfig(path, &config); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+		defer cancel()
+		if err := envconfig.Process(ctx, &config); err != nil {
+			return nil, err
+		}
+
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
+		return &config, config.Validate()
+	}
+}
@@ -25,11 +25,9 @@ package config
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A LoggerConfig provides go-micro logger configuration for
@@ -66,9 +64,32 @@ type LoggerConfig struct {
 		//
 		// By default - empty structure
 		Elastic ElasticLogConfig `yaml:"elastic"`
+		// Syslog is used to configure syslog log output
+		//
+		// By default - empty structure
+		Syslog SyslogLogConfig `yaml:"syslog"`
 	} `yaml:"logger"`
 }
 
+// A SyslogLogConfig provides nested logger configuration for
+// syslog logger providers. This structure is expected to be
+// initialized automatically by fx via yaml and env.
+type SyslogLogConfig struct {
+	// Network is a syslog instance network (udp, tcp or unix).
+	Network string `yaml:"network" env:"SYSLOG_NETWORK,overwrite"`
+	// Address is a syslog instance address.
+	Address string `yaml:"address" env:"SYSLOG_ADDRESS,overwrite"`
+	// Facility is a syslog facility (kern, user, mail, daemon, auth, syslog,
+	// lpr, news, uucp, cron, authpriv, ftp, local0 - local7).
+	//
+	// By default - user
+	Facility string `yaml:"facility" env:"SYSLOG_FACILITY,overwrite"`
+	// Tag is a syslog message tag.
+	//
+	// By default - logger name
+	Tag string `yaml:"tag" env:"SYSLOG_TAG,overwrite"`
+}
+
 // An ElasticLogConfig provides nested logger configuration for
 // elastic logger providers. This structure is expected to be
 // initialized automatically by fx via yaml and env.
@@ -77,7 +98,9 @@ type ElasticLogConfig struct {
 	Address string `yaml:"address" env:"ELASTIC_ADDRESS,overwrite"`
 	// Index is an elasticsearch instance index.
 	Index string `yaml:"index" env:"ELASTIC_INDEX,overwrite"`
-	// Level is an elasticsearch instance logging level.
+	// Level is an elasticsearch instance logging level (1 - Trace to 6 - Fatal).
+	//
+	// By default - 4
 	Level int `yaml:"level" env:"ELASTIC_LEVEL,overwrite"`
 	// Bulk is an elasticsearch instance bulk logging flag.
 	Bulk bool `yaml:"bulk" env:"ELASTIC_BULK,overwrite"`
@@ -118,6 +141,50 @@ type FileLogConfig struct {
 // A successful Validate returns err == nil. Errors other than nil will
 // cause application to panic
 func (lc *LoggerConfig) Validate() error {
+	if lc.Logger.Level < 1 || lc.Logger.Level > 6 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Level",
+			Reason:    "Should be within 1 (Trace) - 6 (Fatal)",
+		}
+	}
+
+	if lc.Logger.Elastic.Index != "" && lc.Logger.Elastic.Address == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Elastic Address",
+			Reason:    "Elastic index requires a valid address",
+		}
+	}
+
+	if lc.Logger.Elastic.Address != "" &&
+		(lc.Logger.Elastic.Level < 1 || lc.Logger.Elastic.Level > 6) {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Elastic Level",
+			Reason:    "Should be within 1 (Trace) - 6 (Fatal)",
+		}
+	}
+
+	if lc.Logger.Syslog.Address != "" {
+		switch lc.Logger.Syslog.Network {
+		case "udp", "tcp", "unix":
+		default:
+			return &InvalidConfigurationParameterError{
+				Parameter: "Syslog Network",
+				Reason:    "Should be one of udp, tcp or unix",
+			}
+		}
+
+		switch lc.Logger.Syslog.Facility {
+		case "", "kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+			"uucp", "cron", "authpriv", "ftp", "local0", "local1", "local2",
+			"local3", "local4", "local5", "local6", "local7":
+		default:
+			return &InvalidConfigurationParameterError{
+				Parameter: "Syslog Facility",
+				Reason:    "Unknown syslog facility",
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -131,18 +198,15 @@ func BuildNewLoggerConfig(path string) func() (*LoggerConfig, error) {
 		var config LoggerConfig
 		config.Logger.Name = "unknown"
 		config.Logger.Level = 4
+		config.Logger.Elastic.Level = 4
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -151,6 +215,10 @@ func BuildNewLoggerConfig(path string) func() (*LoggerConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -0,0 +1,59 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import "testing"
+
+func TestLoggerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(c *LoggerConfig)
+		parameter string
+	}{
+		{"trace level", func(c *LoggerConfig) { c.Logger.Level = 1 }, ""},
+		{"fatal level", func(c *LoggerConfig) { c.Logger.Level = 6 }, ""},
+		{"zero level", func(c *LoggerConfig) { c.Logger.Level = 0 }, "Level"},
+		{"negative level", func(c *LoggerConfig) { c.Logger.Level = -1 }, "Level"},
+		{"level above fatal", func(c *LoggerConfig) { c.Logger.Level = 7 }, "Level"},
+		{"out of range level", func(c *LoggerConfig) { c.Logger.Level = 99 }, "Level"},
+		{"elastic index without address", func(c *LoggerConfig) { c.Logger.Elastic.Index = "logs" }, "Elastic Address"},
+		{"elastic level ignored without address", func(c *LoggerConfig) { c.Logger.Elastic.Level = 0 }, ""},
+		{"elastic boundary level", func(c *LoggerConfig) {
+			c.Logger.Elastic.Address = "http://localhost:9200"
+			c.Logger.Elastic.Level = 6
+		}, ""},
+		{"elastic zero level", func(c *LoggerConfig) {
+			c.Logger.Elastic.Address = "http://localhost:9200"
+			c.Logger.Elastic.Level = 0
+		}, "Elastic Level"},
+		{"elastic level above fatal", func(c *LoggerConfig) {
+			c.Logger.Elastic.Address = "http://localhost:9200"
+			c.Logger.Elastic.Level = 7
+		}, "Elastic Level"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config LoggerConfig
+			config.Logger.Level = 4
+			tt.modify(&config)
+			assertInvalidParameter(t, config.Validate(), tt.parameter)
+		})
+	}
+}
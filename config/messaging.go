@@ -25,11 +25,10 @@ package config
 
 import (
 	"context"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A BrokerConfig provides go-micro broker configuration for
@@ -42,9 +41,21 @@ type BrokerConfig struct {
 		Enable bool `yaml:"enable" env:"BROKER_ENABLE,overwrite"`
 		// Addrs is a list of broker instances.
 		Addrs []string `yaml:"addresses" env:"BROKER_ADDRESSES,overwrite"`
+		// Username is an optional broker's username
+		Username string `yaml:"username" env:"BROKER_USERNAME,overwrite"`
+		// Password is an optional broker's password
+		Password string `yaml:"password" env:"BROKER_PASSWORD,overwrite"`
+		// TLS is a flag to enable TLS connections to broker instances
+		//
+		// By default - false
+		TLS bool `yaml:"tls" env:"BROKER_TLS,overwrite"`
+		// TLSCAFile is an optional path to a PEM encoded CA certificate used
+		// to verify broker instances. Uses system CAs when empty
+		TLSCAFile string `yaml:"tls_ca_file" env:"BROKER_TLS_CA_FILE,overwrite"`
 		// Type is a broker type field.
 		// 1 - RabbitMQ.
 		// 2 - NATS.
+		// 3 - Kafka.
 		//
 		// By default - Memory.
 		Type int `yaml:"type" env:"BROKER_TYPE,overwrite"`
@@ -52,7 +63,8 @@ type BrokerConfig struct {
 		//
 		// By default - false
 		DisableAutoAck bool `yaml:"disable_auto_ack" env:"BROKER_DISABLE_AUTO_ACK,overwrite"`
-		// Durable is a flag to make broker's queues durable
+		// Durable is a flag to make broker's queues durable. NATS
+		// subscribers become a queue group named after ConsumerGroup
 		//
 		// By default - false
 		Durable bool `yaml:"durable" env:"BROKER_DURABLE,overwrite"`
@@ -64,6 +76,20 @@ type BrokerConfig struct {
 		//
 		// By default - false
 		RequeueOnError bool `yaml:"requeue_on_error" env:"BROKER_REQUEUE_ON_ERROR,overwrite"`
+		// ExchangeName is a RabbitMQ exchange to route messages through
+		//
+		// By default - no exchange
+		ExchangeName string `yaml:"exchange_name" env:"BROKER_EXCHANGE_NAME,overwrite"`
+		// ExchangeDurable is a flag to make RabbitMQ exchange durable.
+		// Requires ExchangeName
+		//
+		// By default - false
+		ExchangeDurable bool `yaml:"exchange_durable" env:"BROKER_EXCHANGE_DURABLE,overwrite"`
+		// ConsumerGroup is a kafka consumer group or a NATS queue group
+		// used by subscribers
+		//
+		// By default - a random group per subscriber
+		ConsumerGroup string `yaml:"consumer_group" env:"BROKER_CONSUMER_GROUP,overwrite"`
 	} `yaml:"messaging"`
 }
 
@@ -80,6 +106,30 @@ func (b *BrokerConfig) Validate() error {
 		}
 	}
 
+	if b.Messaging.Enable && b.Messaging.Type == 1 {
+		if b.Messaging.ExchangeDurable && b.Messaging.ExchangeName == "" {
+			return &InvalidConfigurationParameterError{
+				Parameter: "ExchangeName",
+				Reason:    "Durable exchange must have a name",
+			}
+		}
+
+		if strings.HasPrefix(b.Messaging.ExchangeName, "amq.") {
+			return &InvalidConfigurationParameterError{
+				Parameter: "ExchangeName",
+				Reason:    "Exchange names starting with 'amq.' are reserved",
+			}
+		}
+	}
+
+	if b.Messaging.Enable && b.Messaging.Type == 2 &&
+		b.Messaging.Durable && b.Messaging.ConsumerGroup == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "ConsumerGroup",
+			Reason:    "NATS durable subscriptions require a queue group",
+		}
+	}
+
 	return nil
 }
 
@@ -92,17 +142,13 @@ func BuildNewMessagingConfig(path string) func() (*BrokerConfig, error) {
 	return func() (*BrokerConfig, error) {
 		var config BrokerConfig
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -111,6 +157,10 @@ func BuildNewMessagingConfig(path string) func() (*BrokerConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -25,11 +25,9 @@ package config
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A RegistryConfig provides go-micro registry configuration for
@@ -47,9 +45,23 @@ type RegistryConfig struct {
 		// 2 - Consul.
 		// 3 - ETCD.
 		// 4 - MDNS.
+		// 5 - Redis.
 		//
 		// By default - 4
 		Type int `yaml:"type" env:"REGISTRY_TYPE,overwrite"`
+		// Username is an optional registry basic auth username.
+		// Used by Consul, ETCD and Redis registries.
+		Username string `yaml:"username" env:"REGISTRY_USERNAME,overwrite"`
+		// Password is an optional registry basic auth password.
+		// Used by Consul, ETCD and Redis registries.
+		Password string `yaml:"password" env:"REGISTRY_PASSWORD,overwrite"`
+		// Token is an optional Consul ACL token.
+		Token string `yaml:"token" env:"REGISTRY_TOKEN,overwrite"`
+		// TLS is an optional field used to enable TLS connections to
+		// Consul, ETCD and Redis registries.
+		//
+		// By default - false
+		TLS bool `yaml:"tls" env:"REGISTRY_TLS,overwrite"`
 	} `yaml:"registry"`
 }
 
@@ -62,6 +74,24 @@ func (r *RegistryConfig) Validate() error {
 	switch r.Registry.Type {
 	case 1:
 		return nil
+	case 5:
+		if len(r.Registry.Addresses) <= 0 {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Addresses",
+				Reason:    "Redis registry must have at least one address",
+			}
+		}
+
+		return nil
+	case 4:
+		if r.Registry.Username != "" || r.Registry.Password != "" || r.Registry.Token != "" {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Credentials",
+				Reason:    "MDNS registry does not support credentials",
+			}
+		}
+
+		fallthrough
 	default:
 		if len(r.Registry.Addresses) <= 0 {
 			return &InvalidConfigurationParameterError{
@@ -83,17 +113,13 @@ func BuildNewRegistryConfig(path string) func() (*RegistryConfig, error) {
 		var config RegistryConfig
 		config.Registry.CacheTTL = 10 * time.Second
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -102,6 +128,10 @@ func BuildNewRegistryConfig(path string) func() (*RegistryConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
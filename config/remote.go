@@ -0,0 +1,228 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package config provides go-micro adapters' configuration structures
+//
+// The config package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package config
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// remoteConfigEnv is an env variable used to configure a remote config source.
+//
+// The value is a URL: <type>://[user:password@]<address>[,<address>]/<prefix>
+// where type is either consul or etcd, i.e.
+// consul://127.0.0.1:8500/onlyoffice/adapters. Consul also accepts
+// a token query parameter and both types accept tls=true.
+const remoteConfigEnv = "CONFIG_REMOTE"
+
+// remoteKV is a single key-value pair read from a remote config source.
+type remoteKV struct {
+	Key   string
+	Value []byte
+}
+
+// decodeRemoteConfig decodes the remote config source subtree into config.
+// Does nothing when the remote source is not configured.
+//
+// Keys under the prefix are mapped to a yaml tree by path segments
+// (prefix/cors/origins -> cors.origins). Values are parsed as yaml, so lists
+// and numbers may be stored as-is.
+func decodeRemoteConfig(config any) error {
+	source := strings.TrimSpace(os.Getenv(remoteConfigEnv))
+	if source == "" {
+		return nil
+	}
+
+	remote, err := url.Parse(source)
+	if err != nil {
+		return err
+	}
+
+	if remote.Host == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: remoteConfigEnv,
+			Reason:    "Remote config source must have an address",
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer cancel()
+
+	prefix := remotePrefix(remote.Path)
+
+	var pairs []remoteKV
+	switch remote.Scheme {
+	case "consul":
+		pairs, err = listConsul(ctx, remote, prefix)
+	case "etcd":
+		pairs, err = listEtcd(ctx, remote, prefix)
+	default:
+		return &InvalidConfigurationParameterError{
+			Parameter: remoteConfigEnv,
+			Reason:    "Unsupported remote config source type. Expected consul or etcd",
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return decodeRemoteTree(prefix, pairs, config)
+}
+
+// remotePrefix normalizes a key prefix so that consul and etcd sources
+// share the same layout. Leading slashes are dropped and a trailing one
+// is kept for non-empty prefixes.
+func remotePrefix(path string) string {
+	prefix := strings.Trim(path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return prefix
+}
+
+// decodeRemoteTree builds a yaml tree out of key-value pairs and decodes
+// it into config.
+func decodeRemoteTree(prefix string, pairs []remoteKV, config any) error {
+	doc := make(map[string]any)
+	for _, pair := range pairs {
+		key := strings.Trim(strings.TrimPrefix(strings.TrimLeft(pair.Key, "/"), prefix), "/")
+		if key == "" || len(pair.Value) == 0 {
+			continue
+		}
+
+		var value any
+		if err := yaml.Unmarshal(pair.Value, &value); err != nil {
+			value = string(pair.Value)
+		}
+
+		node := doc
+		segments := strings.Split(key, "/")
+		for _, segment := range segments[:len(segments)-1] {
+			child, ok := node[segment].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[segment] = child
+			}
+
+			node = child
+		}
+
+		node[segments[len(segments)-1]] = value
+	}
+
+	if len(doc) == 0 {
+		return nil
+	}
+
+	buf, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(buf, config)
+}
+
+func listConsul(ctx context.Context, remote *url.URL, prefix string) ([]remoteKV, error) {
+	cfg := api.DefaultConfig()
+	cfg.Address = remote.Host
+	cfg.Token = remote.Query().Get("token")
+	if remote.Query().Get("tls") == "true" {
+		cfg.Scheme = "https"
+	}
+
+	if remote.User != nil {
+		password, _ := remote.User.Password()
+		cfg.HttpAuth = &api.HttpBasicAuth{
+			Username: remote.User.Username(),
+			Password: password,
+		}
+	}
+
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kvs, _, err := client.KV().List(prefix, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]remoteKV, 0, len(kvs))
+	for _, kv := range kvs {
+		pairs = append(pairs, remoteKV{Key: kv.Key, Value: kv.Value})
+	}
+
+	return pairs, nil
+}
+
+func listEtcd(ctx context.Context, remote *url.URL, prefix string) ([]remoteKV, error) {
+	scheme := "http://"
+	if remote.Query().Get("tls") == "true" {
+		scheme = "https://"
+	}
+
+	var endpoints []string
+	for _, address := range strings.Split(remote.Host, ",") {
+		endpoints = append(endpoints, scheme+address)
+	}
+
+	cfg := clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 4 * time.Second,
+		Context:     ctx,
+	}
+
+	if remote.User != nil {
+		cfg.Username = remote.User.Username()
+		cfg.Password, _ = remote.User.Password()
+	}
+
+	client, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	resp, err := client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]remoteKV, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		pairs = append(pairs, remoteKV{Key: string(kv.Key), Value: kv.Value})
+	}
+
+	return pairs, nil
+}
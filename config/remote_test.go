@@ -0,0 +1,98 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/api"
+)
+
+func newTestConsulKV(t *testing.T, pairs api.KVPairs) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		var found api.KVPairs
+		for _, pair := range pairs {
+			if strings.HasPrefix(pair.Key, prefix) {
+				found = append(found, pair)
+			}
+		}
+
+		if len(found) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("X-Consul-Index", "1")
+		w.Header().Set("X-Consul-LastContact", "0")
+		w.Header().Set("X-Consul-KnownLeader", "true")
+		json.NewEncoder(w).Encode(found)
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRemotePrefix(t *testing.T) {
+	for path, expected := range map[string]string{
+		"":                      "",
+		"/":                     "",
+		"/onlyoffice/adapters":  "onlyoffice/adapters/",
+		"onlyoffice/adapters/":  "onlyoffice/adapters/",
+		"//onlyoffice/adapters": "onlyoffice/adapters/",
+	} {
+		if prefix := remotePrefix(path); prefix != expected {
+			t.Fatalf("expected %q prefix for %q, got %q", expected, path, prefix)
+		}
+	}
+}
+
+func TestDecodeRemoteConfigConsul(t *testing.T) {
+	srv := newTestConsulKV(t, api.KVPairs{
+		{Key: "onlyoffice/adapters/cors/origins", Value: []byte("[https://example.com]")},
+		{Key: "onlyoffice/adapters/cors/credentials", Value: []byte("true")},
+		{Key: "onlyoffice/other/cors/methods", Value: []byte("[DELETE]")},
+	})
+
+	t.Setenv(remoteConfigEnv, "consul://"+strings.TrimPrefix(srv.URL, "http://")+"/onlyoffice/adapters")
+	t.Setenv("ALLOWED_METHODS", "GET")
+
+	config, err := BuildNewCorsConfig("")()
+	if err != nil {
+		t.Fatalf("could not build a remote config: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(config.CORS.AllowedOrigins, []string{"https://example.com"}) || !config.CORS.AllowCredentials {
+		t.Fatalf("expected remote values to be decoded, got %+v", config.CORS)
+	}
+
+	if !reflect.DeepEqual(config.CORS.AllowedMethods, []string{"GET"}) {
+		t.Fatalf("expected env values to override remote ones, got %v", config.CORS.AllowedMethods)
+	}
+}
+
+func TestDecodeRemoteConfigUnsupported(t *testing.T) {
+	t.Setenv(remoteConfigEnv, "zookeeper://127.0.0.1:2181/onlyoffice")
+	assertInvalidParameter(t, decodeRemoteConfig(&CORSConfig{}), remoteConfigEnv)
+}
@@ -23,11 +23,9 @@ package config
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A CacheConfig provides an entry point configuration for
@@ -54,18 +52,18 @@ func BuildNewResilienceConfig(path string) func() (*ResilienceConfig, error) {
 		config.Resilience.RateLimiter.Limit = 3000
 		config.Resilience.RateLimiter.IPLimit = 20
 		config.Resilience.CircuitBreaker.Timeout = 5000
+		config.Resilience.CircuitBreaker.MaxConcurrent = 10
+		config.Resilience.CircuitBreaker.VolumeThreshold = 20
+		config.Resilience.CircuitBreaker.SleepWindow = 5000
+		config.Resilience.CircuitBreaker.ErrorPercentThreshold = 50
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -74,6 +72,10 @@ func BuildNewResilienceConfig(path string) func() (*ResilienceConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -89,14 +91,33 @@ type RateLimiterConfig struct {
 	//
 	// By default - 20
 	IPLimit uint64 `yaml:"iplimit" env:"RATE_LIMIT_IP,overwrite"`
+	// RedisAddress is an optional redis instance address used to share
+	// limits across service instances
+	//
+	// By default - no redis (in-memory limits)
+	RedisAddress string `yaml:"redis_address" env:"RATE_LIMIT_REDIS_ADDRESS,overwrite"`
+	// RedisUsername is an optional redis instance username
+	RedisUsername string `yaml:"redis_username" env:"RATE_LIMIT_REDIS_USERNAME,overwrite"`
+	// RedisPassword is an optional redis instance password
+	RedisPassword string `yaml:"redis_password" env:"RATE_LIMIT_REDIS_PASSWORD,overwrite"`
+	// RedisDatabase is an optional redis database index
+	//
+	// By default - 0
+	RedisDatabase int `yaml:"redis_database" env:"RATE_LIMIT_REDIS_DATABASE,overwrite"`
+	// RedisTLS is an optional field used to enable TLS connections to
+	// the redis instance.
+	//
+	// By default - false
+	RedisTLS bool `yaml:"redis_tls" env:"RATE_LIMIT_REDIS_TLS,overwrite"`
 }
 
 // A CircuitBreakerConfig provides hystrix circuit breaker configuration.
 // This structure is expected to be initialized automatically by fx via yaml and env.
 type CircuitBreakerConfig struct {
-	// Timeout is how long to wait for command to complete, in milliseconds
+	// Timeout is how long to wait for command to complete, in milliseconds.
+	// Client calls wait for at least the client request timeout.
 	//
-	// By default - 1000
+	// By default - 5000
 	Timeout int `yaml:"timeout" env:"CIRCUIT_TIMEOUT,overwrite"`
 	// MaxConcurrent is how many commands of the same type can run at the same time
 	//
@@ -122,5 +143,38 @@ type CircuitBreakerConfig struct {
 // A successful Validate returns err == nil. Errors other than nil will
 // cause application to panic
 func (rc *ResilienceConfig) Validate() error {
+	limiter := rc.Resilience.RateLimiter
+	if limiter.Limit > 0 && limiter.IPLimit > limiter.Limit {
+		return &InvalidConfigurationParameterError{
+			Parameter: "IPLimit",
+			Reason:    "Should not exceed global limit",
+		}
+	}
+
+	breaker := rc.Resilience.CircuitBreaker
+	for _, param := range []struct {
+		name  string
+		value int
+	}{
+		{"Timeout", breaker.Timeout},
+		{"MaxConcurrent", breaker.MaxConcurrent},
+		{"VolumeThreshold", breaker.VolumeThreshold},
+		{"SleepWindow", breaker.SleepWindow},
+	} {
+		if param.value <= 0 {
+			return &InvalidConfigurationParameterError{
+				Parameter: param.name,
+				Reason:    "Should be positive",
+			}
+		}
+	}
+
+	if breaker.ErrorPercentThreshold < 0 || breaker.ErrorPercentThreshold > 100 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "ErrorPercentThreshold",
+			Reason:    "Should be within 0-100",
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,87 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestResilienceConfig() *ResilienceConfig {
+	var config ResilienceConfig
+	config.Resilience.RateLimiter.Limit = 3000
+	config.Resilience.RateLimiter.IPLimit = 20
+	config.Resilience.CircuitBreaker = CircuitBreakerConfig{
+		Timeout:               5000,
+		MaxConcurrent:         10,
+		VolumeThreshold:       20,
+		SleepWindow:           5000,
+		ErrorPercentThreshold: 50,
+	}
+
+	return &config
+}
+
+func TestResilienceConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(c *ResilienceConfig)
+		parameter string
+	}{
+		{"valid", func(c *ResilienceConfig) {}, ""},
+		{"ip limit above limit", func(c *ResilienceConfig) { c.Resilience.RateLimiter.IPLimit = 5000 }, "IPLimit"},
+		{"zero timeout", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.Timeout = 0 }, "Timeout"},
+		{"negative timeout", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.Timeout = -1 }, "Timeout"},
+		{"zero max concurrent", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.MaxConcurrent = 0 }, "MaxConcurrent"},
+		{"zero volume threshold", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.VolumeThreshold = 0 }, "VolumeThreshold"},
+		{"zero sleep window", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.SleepWindow = 0 }, "SleepWindow"},
+		{"negative error percent", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.ErrorPercentThreshold = -1 }, "ErrorPercentThreshold"},
+		{"error percent above 100", func(c *ResilienceConfig) { c.Resilience.CircuitBreaker.ErrorPercentThreshold = 300 }, "ErrorPercentThreshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newTestResilienceConfig()
+			tt.modify(config)
+			assertInvalidParameter(t, config.Validate(), tt.parameter)
+		})
+	}
+}
+
+// assertInvalidParameter checks that err reports the parameter provided.
+// An empty parameter expects no error.
+func assertInvalidParameter(t *testing.T, err error, parameter string) {
+	t.Helper()
+	if parameter == "" {
+		if err != nil {
+			t.Fatalf("expected a valid config, got %s", err.Error())
+		}
+
+		return
+	}
+
+	var perr *InvalidConfigurationParameterError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected an invalid %s parameter error, got %v", parameter, err)
+	}
+
+	if perr.Parameter != parameter {
+		t.Fatalf("expected an invalid %s parameter, got %s", parameter, perr.Parameter)
+	}
+}
@@ -0,0 +1,94 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package config provides go-micro adapters' configuration structures
+//
+// The config package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+)
+
+// _secretFileSuffix is appended to env variable names to read
+// their values from files (i.e. docker or kubernetes secrets).
+const _secretFileSuffix = "_FILE"
+
+// loadSecretFiles populates string fields from files referenced by env
+// variables with the _FILE suffix (e.g. CACHE_PASSWORD_FILE). Called after
+// envconfig processing, so file values take precedence. Trailing newlines
+// are trimmed.
+func loadSecretFiles(config any) error {
+	return loadSecretFilesValue(reflect.ValueOf(config))
+}
+
+func loadSecretFilesValue(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			if err := loadSecretFilesValue(field.Addr()); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("env"), ",")
+		if name == "" || field.Kind() != reflect.String {
+			continue
+		}
+
+		path := os.Getenv(name + _secretFileSuffix)
+		if path == "" {
+			continue
+		}
+
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return &InvalidConfigurationParameterError{
+				Parameter: name + _secretFileSuffix,
+				Reason:    err.Error(),
+			}
+		}
+
+		field.SetString(strings.TrimRight(string(buf), "\r\n"))
+	}
+
+	return nil
+}
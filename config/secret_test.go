@@ -0,0 +1,72 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSecretFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CACHE_PASSWORD_FILE", writeTestConfig(t, dir, "cache_password", "secret\n"))
+	t.Setenv("STORAGE_URL_FILE", writeTestConfig(t, dir, "storage_url", "mongodb://localhost:27017\r\n"))
+
+	var cache CacheConfig
+	if err := loadSecretFiles(&cache); err != nil {
+		t.Fatalf("could not load cache secrets: %s", err.Error())
+	}
+
+	if cache.Cache.Password != "secret" {
+		t.Fatalf("expected the password to be read from a file, got %q", cache.Cache.Password)
+	}
+
+	var storage StorageConfig
+	if err := loadSecretFiles(&storage); err != nil {
+		t.Fatalf("could not load storage secrets: %s", err.Error())
+	}
+
+	if storage.Storage.URL != "mongodb://localhost:27017" {
+		t.Fatalf("expected the url to be read from a file, got %q", storage.Storage.URL)
+	}
+}
+
+func TestLoadSecretFilesOverrideEnv(t *testing.T) {
+	t.Setenv("CACHE_PASSWORD", "env")
+	t.Setenv("CACHE_PASSWORD_FILE", writeTestConfig(t, t.TempDir(), "cache_password", "file"))
+
+	config, err := BuildNewCacheConfig("")()
+	if err != nil {
+		t.Fatalf("could not build a cache config: %s", err.Error())
+	}
+
+	if config.Cache.Password != "file" {
+		t.Fatalf("expected the file value to take precedence, got %q", config.Cache.Password)
+	}
+}
+
+func TestLoadSecretFilesMissing(t *testing.T) {
+	t.Setenv("CACHE_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	var perr *InvalidConfigurationParameterError
+	if err := loadSecretFiles(&CacheConfig{}); !errors.As(err, &perr) || perr.Parameter != "CACHE_PASSWORD_FILE" {
+		t.Fatalf("expected a missing secret file error, got %v", err)
+	}
+}
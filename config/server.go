@@ -25,12 +25,10 @@ package config
 
 import (
 	"context"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A ServerConfig provides go-micro service. This structure is expected to be
@@ -48,8 +46,30 @@ type ServerConfig struct {
 	Version string `yaml:"version" env:"SERVER_VERSION,overwrite"`
 	// Address is the service's address/port.
 	Address string `yaml:"address" env:"SERVER_ADDRESS,overwrite"`
+	// RequestTimeout is the service's per-request timeout. Requests exceeding
+	// it are cancelled and get 503.
+	//
+	// By default - no timeout.
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"SERVER_REQUEST_TIMEOUT,overwrite"`
 	// ReplAddress is system service's address.
 	ReplAddress string `yaml:"repl_address" env:"REPL_ADDRESS,overwrite"`
+	// MetricsPath is system service's prometheus metrics path.
+	//
+	// By default - /metrics.
+	MetricsPath string `yaml:"metrics_path" env:"REPL_METRICS_PATH,overwrite"`
+	// HealthPath is system service's health check path.
+	//
+	// By default - /health.
+	HealthPath string `yaml:"health_path" env:"REPL_HEALTH_PATH,overwrite"`
+	// Compression is flag to enable/disable gzip compression of system
+	// service's responses.
+	//
+	// By default - false.
+	Compression bool `yaml:"compression" env:"REPL_COMPRESSION,overwrite"`
+	// ShutdownTimeout is system service's graceful shutdown timeout.
+	//
+	// By default - 10s.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"REPL_SHUTDOWN_TIMEOUT,overwrite"`
 	// Debug is flag to enable/disable debug features of the system's service.
 	//
 	// By default - false.
@@ -66,6 +86,16 @@ func (hs *ServerConfig) Validate() error {
 	hs.Name = strings.TrimSpace(hs.Name)
 	hs.Address = strings.TrimSpace(hs.Address)
 	hs.ReplAddress = strings.TrimSpace(hs.ReplAddress)
+	hs.MetricsPath = strings.TrimSpace(hs.MetricsPath)
+	hs.HealthPath = strings.TrimSpace(hs.HealthPath)
+
+	if hs.MetricsPath == "" {
+		hs.MetricsPath = "/metrics"
+	}
+
+	if hs.HealthPath == "" {
+		hs.HealthPath = "/health"
+	}
 
 	if hs.Namespace == "" {
 		return &InvalidConfigurationParameterError{
@@ -106,18 +136,15 @@ func (hs *ServerConfig) Validate() error {
 func BuildNewServerConfig(path string) func() (*ServerConfig, error) {
 	return func() (*ServerConfig, error) {
 		var config ServerConfig
+		config.ShutdownTimeout = 10 * time.Second
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -126,6 +153,10 @@ func BuildNewServerConfig(path string) func() (*ServerConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
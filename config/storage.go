@@ -25,12 +25,11 @@ package config
 
 import (
 	"context"
-	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A StorageConfig provides configuration for
@@ -41,11 +40,32 @@ type StorageConfig struct {
 	// Persistence is a nested structure used as a marker for yaml configuration.
 	Storage struct {
 		// Type is a persistence driver type.
+		// 1 - MongoDB.
+		// 2 - Redis.
+		//
+		// By default - empty (no-op) driver.
 		Type int `yaml:"type" env:"STORAGE_TYPE,overwrite"`
 		// URL is a persistence driver adapter's url to connect to.
+		// Redis driver accepts both host:port and redis:// urls.
 		URL string `yaml:"url" env:"STORAGE_URL,overwrite"`
-		// DB is a database name to connect to.
+		// DB is a database name to connect to. Redis driver expects
+		// a database index.
 		DB string `yaml:"db" env:"STORAGE_DB,overwrite"`
+		// ReadPreference is an optional MongoDB read preference mode:
+		// primary, primaryPreferred, secondary, secondaryPreferred or nearest.
+		//
+		// By default - driver default (primary)
+		ReadPreference string `yaml:"read_preference" env:"STORAGE_READ_PREFERENCE,overwrite"`
+		// WriteConcern is an optional MongoDB write concern: majority
+		// or w<N> (i.e. w1 or 1).
+		//
+		// By default - driver default
+		WriteConcern string `yaml:"write_concern" env:"STORAGE_WRITE_CONCERN,overwrite"`
+		// SoftDelete is a list of MongoDB collections with soft-deleted
+		// documents hidden from reads. "*" enables it for every collection.
+		//
+		// By default - disabled
+		SoftDelete []string `yaml:"soft_delete" env:"STORAGE_SOFT_DELETE,overwrite"`
 	} `yaml:"storage"`
 }
 
@@ -57,6 +77,24 @@ type StorageConfig struct {
 func (p *StorageConfig) Validate() error {
 	p.Storage.URL = strings.TrimSpace(p.Storage.URL)
 	p.Storage.DB = strings.TrimSpace(p.Storage.DB)
+	p.Storage.ReadPreference = strings.TrimSpace(p.Storage.ReadPreference)
+	p.Storage.WriteConcern = strings.ToLower(strings.TrimSpace(p.Storage.WriteConcern))
+
+	switch p.Storage.ReadPreference {
+	case "", "primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest":
+	default:
+		return &InvalidConfigurationParameterError{
+			Parameter: "ReadPreference",
+			Reason:    "Expected primary, primaryPreferred, secondary, secondaryPreferred or nearest",
+		}
+	}
+
+	if wc := p.Storage.WriteConcern; wc != "" {
+		if _, _, err := ParseWriteConcern(wc); err != nil {
+			return err
+		}
+	}
+
 	switch p.Storage.Type {
 	case 1:
 		if p.Storage.URL == "" {
@@ -65,6 +103,13 @@ func (p *StorageConfig) Validate() error {
 				Reason:    "MongoDB driver expects a valid url",
 			}
 		}
+	case 2:
+		if p.Storage.URL == "" {
+			return &InvalidConfigurationParameterError{
+				Parameter: "URL",
+				Reason:    "Redis driver expects a valid url",
+			}
+		}
 	default:
 		if p.Storage.URL == "" {
 			return &InvalidConfigurationParameterError{
@@ -77,6 +122,27 @@ func (p *StorageConfig) Validate() error {
 	return nil
 }
 
+// ParseWriteConcern parses a MongoDB write concern: majority or
+// w<N> with an optional w prefix (i.e. w1 or 1).
+// It returns majority == true for majority and the number of
+// acknowledging nodes otherwise.
+func ParseWriteConcern(concern string) (int, bool, error) {
+	concern = strings.ToLower(strings.TrimSpace(concern))
+	if concern == "majority" {
+		return 0, true, nil
+	}
+
+	w, err := strconv.Atoi(strings.TrimPrefix(concern, "w"))
+	if err != nil || w < 0 {
+		return 0, false, &InvalidConfigurationParameterError{
+			Parameter: "WriteConcern",
+			Reason:    "Expected majority or w<N> (i.e. w1)",
+		}
+	}
+
+	return w, false, nil
+}
+
 // A StorageConfig constructor. Called automatically by fx and
 // bootstrapper with config path provided via cli.
 //
@@ -86,17 +152,13 @@ func BuildNewStorageConfig(path string) func() (*StorageConfig, error) {
 	return func() (*StorageConfig, error) {
 		var config StorageConfig
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -105,6 +167,10 @@ func BuildNewStorageConfig(path string) func() (*StorageConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
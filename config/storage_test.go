@@ -0,0 +1,57 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import "testing"
+
+func TestStorageConfigValidateWriteConcern(t *testing.T) {
+	tests := []struct {
+		name      string
+		concern   string
+		parameter string
+	}{
+		{"unset", "", ""},
+		{"majority", "Majority", ""},
+		{"prefixed", "w1", ""},
+		{"bare number", "1", ""},
+		{"negative", "w-1", "WriteConcern"},
+		{"unknown", "all", "WriteConcern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config StorageConfig
+			config.Storage.URL = "mongodb://localhost:27017"
+			config.Storage.WriteConcern = tt.concern
+			assertInvalidParameter(t, config.Validate(), tt.parameter)
+		})
+	}
+}
+
+func TestParseWriteConcern(t *testing.T) {
+	if _, majority, err := ParseWriteConcern("majority"); err != nil || !majority {
+		t.Fatalf("expected a majority write concern, got %v, %v", majority, err)
+	}
+
+	for _, concern := range []string{"w2", "2"} {
+		if w, majority, err := ParseWriteConcern(concern); err != nil || majority || w != 2 {
+			t.Fatalf("expected %s to acknowledge 2 nodes, got %d, %v", concern, w, err)
+		}
+	}
+}
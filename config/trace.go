@@ -25,11 +25,10 @@ package config
 
 import (
 	"context"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A TracerConfig provides go-telemetry configuration for
@@ -47,9 +46,14 @@ type TracerConfig struct {
 		// TracerType is a tracer provider selector.
 		// 0 - Console.
 		// 1 - Zipkin.
+		// 2 - OTLP (gRPC). Address is the collector endpoint.
+		// 3 - Jaeger. Address is Jaeger's OTLP/gRPC endpoint (e.g. jaeger:4317).
 		//
 		// By default - 0.
-		TracerType    int     `yaml:"type" env:"TRACER_TYPE,overwrite"`
+		TracerType int `yaml:"type" env:"TRACER_TYPE,overwrite"`
+		// FractionRatio is a fraction of traces sampled via a TraceIDRatioBased sampler.
+		//
+		// By default - 1.
 		FractionRatio float64 `yaml:"fraction" env:"TRACER_FRACTION_RATIO,overwrite"`
 	} `yaml:"tracer"`
 }
@@ -60,6 +64,27 @@ type TracerConfig struct {
 // A successful Validate returns err == nil. Errors other than nil will
 // cause application to panic
 func (tc *TracerConfig) Validate() error {
+	if tc.Tracer.TracerType < 0 || tc.Tracer.TracerType > 3 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "TracerType",
+			Reason:    "Unsupported tracer type",
+		}
+	}
+
+	if tc.Tracer.TracerType >= 2 && strings.TrimSpace(tc.Tracer.Address) == "" {
+		return &InvalidConfigurationParameterError{
+			Parameter: "Address",
+			Reason:    "OTLP and Jaeger tracers expect a collector address",
+		}
+	}
+
+	if tc.Tracer.FractionRatio < 0 || tc.Tracer.FractionRatio > 1 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "FractionRatio",
+			Reason:    "Should be within [0, 1]",
+		}
+	}
+
 	return nil
 }
 
@@ -73,17 +98,13 @@ func BuildNewTracerConfig(path string) func() (*TracerConfig, error) {
 		var config TracerConfig
 		config.Tracer.FractionRatio = 1
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -92,6 +113,10 @@ func BuildNewTracerConfig(path string) func() (*TracerConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
@@ -0,0 +1,51 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import "testing"
+
+func TestTracerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(c *TracerConfig)
+		parameter string
+	}{
+		{"console", func(c *TracerConfig) {}, ""},
+		{"otlp with address", func(c *TracerConfig) {
+			c.Tracer.TracerType = 2
+			c.Tracer.Address = "localhost:4317"
+		}, ""},
+		{"otlp without address", func(c *TracerConfig) { c.Tracer.TracerType = 2 }, "Address"},
+		{"otlp with blank address", func(c *TracerConfig) {
+			c.Tracer.TracerType = 2
+			c.Tracer.Address = "  "
+		}, "Address"},
+		{"unknown tracer type", func(c *TracerConfig) { c.Tracer.TracerType = 9 }, "TracerType"},
+		{"fraction ratio above one", func(c *TracerConfig) { c.Tracer.FractionRatio = 2 }, "FractionRatio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config TracerConfig
+			config.Tracer.FractionRatio = 1
+			tt.modify(&config)
+			assertInvalidParameter(t, config.Validate(), tt.parameter)
+		})
+	}
+}
@@ -25,11 +25,11 @@ package config
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sethvargo/go-envconfig"
-	"gopkg.in/yaml.v2"
 )
 
 // A WorkerConfig provides an async worker's configuration for
@@ -47,6 +47,11 @@ type WorkerConfig struct {
 		Type int `yaml:"type" env:"WORKER_TYPE,overwrite"`
 		// MaxConcurrency is the maximum number of workers.
 		MaxConcurrency int `yaml:"max_concurrency" env:"WORKER_MAX_CONCURRENCY,overwrite"`
+		// Queues maps queue names to their priority weights. Tasks are
+		// processed from queues proportionally to their weights.
+		//
+		// By default - {"default": 1}.
+		Queues map[string]int `yaml:"queues" env:"WORKER_QUEUES,overwrite"`
 		// RedisAddresses is redis instances addresses.
 		RedisAddresses []string `yaml:"addresses" env:"WORKER_ADDRESS,overwrite"`
 		// RedisUsername is redis basic auth username.
@@ -57,6 +62,21 @@ type WorkerConfig struct {
 		//
 		// By default - 0.
 		RedisDatabase int `yaml:"database" env:"WORKER_DATABASE,overwrite"`
+		// TLS is an optional field used to enable TLS connections to
+		// redis instances.
+		//
+		// By default - false
+		TLS bool `yaml:"tls" env:"WORKER_TLS,overwrite"`
+		// TLSCAFile is an optional path to a PEM encoded CA certificate
+		// used to verify redis instances. System roots are used otherwise.
+		TLSCAFile string `yaml:"tls_ca_file" env:"WORKER_TLS_CA_FILE,overwrite"`
+		// MasterName is an optional field used to enable redis sentinel
+		// failover. Requires at least one sentinel address.
+		//
+		// By default - no sentinel
+		MasterName string `yaml:"master_name" env:"WORKER_MASTER_NAME,overwrite"`
+		// SentinelAddresses is a list of redis sentinel instances addresses.
+		SentinelAddresses []string `yaml:"sentinel_addresses" env:"WORKER_SENTINEL_ADDRESSES,overwrite"`
 	} `yaml:"worker"`
 }
 
@@ -66,13 +86,40 @@ type WorkerConfig struct {
 // A successful Validate returns err == nil. Errors other than nil will
 // cause application to panic
 func (wc *WorkerConfig) Validate() error {
-	if wc.Worker.Enable && len(wc.Worker.RedisAddresses) < 1 {
+	if wc.Worker.Enable && wc.Worker.MasterName == "" && len(wc.Worker.RedisAddresses) < 1 {
 		return &InvalidConfigurationParameterError{
 			Parameter: "Worker address",
 			Reason:    "Should not be empty",
 		}
 	}
 
+	if wc.Worker.MasterName != "" && len(wc.Worker.SentinelAddresses) < 1 {
+		return &InvalidConfigurationParameterError{
+			Parameter: "SentinelAddresses",
+			Reason:    "Redis sentinel worker must have at least one sentinel address",
+		}
+	}
+
+	if len(wc.Worker.Queues) == 0 {
+		wc.Worker.Queues = map[string]int{"default": 1}
+	}
+
+	for name, weight := range wc.Worker.Queues {
+		if strings.TrimSpace(name) == "" {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Queues",
+				Reason:    "Queue name should not be empty",
+			}
+		}
+
+		if weight <= 0 {
+			return &InvalidConfigurationParameterError{
+				Parameter: "Queues",
+				Reason:    fmt.Sprintf("Queue %s weight should be positive", name),
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -86,17 +133,13 @@ func BuildNewWorkerConfig(path string) func() (*WorkerConfig, error) {
 		var config WorkerConfig
 		config.Worker.MaxConcurrency = 3
 		if path != "" {
-			file, err := os.Open(path)
-			if err != nil {
+			if err := decodeConfig(path, &config); err != nil {
 				return nil, err
 			}
-			defer file.Close()
-
-			decoder := yaml.NewDecoder(file)
+		}
 
-			if err := decoder.Decode(&config); err != nil {
-				return nil, err
-			}
+		if err := decodeRemoteConfig(&config); err != nil {
+			return nil, err
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 4*time.Second)
@@ -105,6 +148,10 @@ func BuildNewWorkerConfig(path string) func() (*WorkerConfig, error) {
 			return nil, err
 		}
 
+		if err := loadSecretFiles(&config); err != nil {
+			return nil, err
+		}
+
 		return &config, config.Validate()
 	}
 }
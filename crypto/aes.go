@@ -34,77 +34,193 @@ import (
 )
 
 var _ErrInvalidNonceSize = errors.New("invalid nonce size")
+var ErrInvalidKeySize = errors.New("invalid key size")
 
 // An aesEncryptor provides an AES encryption implementation This structure is expected to be
 // initialized automatically by fx via yaml and env.
-type aesEncryptor struct{}
+//
+// Ciphertexts produced before key validation was introduced used keys
+// zero-padded or truncated to 32 bytes, i.e. AES-256 for any key length.
+// With legacyKeys enabled, decryption falls back to that scheme for keys
+// other than 32 bytes once authentication with the key as is fails, so
+// existing data stays readable. To migrate, decrypt such values and encrypt
+// them again: new ciphertexts always use the key as is.
+type aesEncryptor struct {
+	legacyKeys bool
+}
 
 // An AES encryptor constructor. Called internally and automatically by fx and
 // bootstrapper with based on specific encryptor type.
 //
-// Returns an AES GCM encryptor implementation.
-func newAesEncryptor() Encryptor {
-	return aesEncryptor{}
+// Returns an AES GCM encryptor implementation. legacyKeys enables decryption
+// of ciphertexts produced with zero-padded or truncated keys.
+func newAesEncryptor(legacyKeys bool) Encryptor {
+	return aesEncryptor{legacyKeys: legacyKeys}
 }
 
 // Encrypt transforms plaintext into an encrypted one with the given key.
-// It returns encrypted text and the first encountered error.
+// The key is expected to be 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+// It returns base64 encoded encrypted text and the first encountered error.
 //
 // A successful Encrypt returns encrypted text and err == nil.
 func (e aesEncryptor) Encrypt(text string, key []byte) (string, error) {
-	validKey := make([]byte, 32)
-	copy(validKey, key)
-
-	c, err := aes.NewCipher(validKey)
+	result, err := e.EncryptBytes([]byte(text), key)
 	if err != nil {
 		return "", err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	return base64.StdEncoding.EncodeToString(result), nil
+}
+
+// Decrypt transforms base64 encoded encrypted text into a decrypted one with the given key.
+// The key is expected to be 16, 24 or 32 bytes long (AES-128, AES-192 or AES-256).
+// Legacy ciphertexts encrypted with zero-padded or truncated keys are decrypted
+// as well when legacy keys are enabled.
+// It returns decrypted text and the first encountered error.
+//
+// A successful Decrypt returns decrypted text and err == nil.
+func (e aesEncryptor) Decrypt(text string, key []byte) (string, error) {
+	buf, err := base64.StdEncoding.DecodeString(text)
 	if err != nil {
 		return "", err
 	}
 
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+	plaintext, err := e.DecryptBytes(buf, key)
+	if err != nil {
 		return "", err
 	}
 
-	result := gcm.Seal(nonce, nonce, []byte(text), nil)
+	return string(plaintext), nil
+}
+
+// EncryptBytes transforms data into a nonce-prefixed ciphertext with the given key.
+// It returns raw encrypted bytes and the first encountered error.
+//
+// A successful EncryptBytes returns encrypted bytes and err == nil.
+func (e aesEncryptor) EncryptBytes(data, key []byte) ([]byte, error) {
+	gcm, err := newAesGCM(key)
+	if err != nil {
+		return nil, err
+	}
 
-	return base64.StdEncoding.EncodeToString(result), nil
+	return sealAEAD(gcm, data)
 }
 
-// Decrypt transforms encrypted text into a decrypted one with the given key.
-// It returns decrypted text and the first encountered error.
+// DecryptBytes transforms a nonce-prefixed ciphertext into plain data with the given key.
+// It returns decrypted bytes and the first encountered error.
 //
-// A successful Decrypt returns decrypted text and err == nil.
-func (e aesEncryptor) Decrypt(text string, key []byte) (string, error) {
-	validKey := make([]byte, 32)
-	copy(validKey, key)
+// A successful DecryptBytes returns decrypted bytes and err == nil.
+func (e aesEncryptor) DecryptBytes(data, key []byte) ([]byte, error) {
+	plaintext, err := decryptAes(data, key)
+	if err != nil && e.legacyKeys && len(key) > 0 && len(key) != 32 {
+		if legacy, lerr := decryptAes(data, legacyAesKey(key)); lerr == nil {
+			return legacy, nil
+		}
+	}
+
+	return plaintext, err
+}
 
-	c, err := aes.NewCipher(validKey)
+// EncryptStream encrypts src into dst in chunks with the given key without buffering
+// the whole input. Every chunk is sealed with its own nonce.
+// It returns the first encountered read, write or encryption error.
+//
+// A successful EncryptStream returns err == nil.
+func (e aesEncryptor) EncryptStream(dst io.Writer, src io.Reader, key []byte) error {
+	gcm, err := newAesGCM(key)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	gcm, err := cipher.NewGCM(c)
+	return encryptStream(gcm, dst, src)
+}
+
+// DecryptStream decrypts src produced by EncryptStream into dst with the given key.
+// It returns the first encountered read, write or decryption error.
+//
+// A successful DecryptStream returns err == nil.
+func (e aesEncryptor) DecryptStream(dst io.Writer, src io.Reader, key []byte) error {
+	gcm, err := newAesGCM(key)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	buf, err := base64.StdEncoding.DecodeString(text)
+	return decryptStream(gcm, dst, src)
+}
+
+// decryptAes decrypts a nonce-prefixed ciphertext with the key as is.
+func decryptAes(data, key []byte) ([]byte, error) {
+	gcm, err := newAesGCM(key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	nonceSize := gcm.NonceSize()
-	if len(buf) < nonceSize {
-		return "", _ErrInvalidNonceSize
+	return openAEAD(gcm, data)
+}
+
+// legacyAesKey zero-pads or truncates a key to 32 bytes as keys
+// used to be before key validation.
+func legacyAesKey(key []byte) []byte {
+	padded := make([]byte, 32)
+	copy(padded, key)
+	return padded
+}
+
+// newAesGCM validates the key and builds an AES GCM cipher.
+func newAesGCM(key []byte) (cipher.AEAD, error) {
+	if err := validateAesKey(key); err != nil {
+		return nil, err
 	}
 
-	nonce, ciphertext := buf[:nonceSize], buf[nonceSize:]
-	plaintext, _ := gcm.Open(nil, nonce, ciphertext, nil)
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
 
-	return string(plaintext), nil
+	return cipher.NewGCM(c)
+}
+
+// sealAEAD encrypts data with a random nonce and prepends the nonce
+// to the result.
+func sealAEAD(aead cipher.AEAD, data []byte) ([]byte, error) {
+	return sealAEADWithData(aead, data, nil)
+}
+
+// sealAEADWithData encrypts and authenticates data and additional data with a
+// random nonce and prepends the nonce to the result.
+func sealAEADWithData(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+// openAEAD splits a nonce-prefixed ciphertext and decrypts it.
+func openAEAD(aead cipher.AEAD, data []byte) ([]byte, error) {
+	return openAEADWithData(aead, data, nil)
+}
+
+// openAEADWithData splits a nonce-prefixed ciphertext and decrypts it
+// authenticating additional data.
+func openAEADWithData(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, _ErrInvalidNonceSize
+	}
+
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// validateAesKey checks whether the key length matches one of AES key sizes.
+// It returns ErrInvalidKeySize otherwise.
+func validateAesKey(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return ErrInvalidKeySize
+	}
 }
@@ -0,0 +1,124 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestAesDecryptTampered(t *testing.T) {
+	encryptor := newAesEncryptor(false)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypted, err := encryptor.Encrypt("secret", key)
+	if err != nil {
+		t.Fatalf("could not encrypt: %v", err)
+	}
+
+	buf, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("could not decode ciphertext: %v", err)
+	}
+
+	buf[len(buf)-1] ^= 0x01
+	if _, err := encryptor.Decrypt(base64.StdEncoding.EncodeToString(buf), key); err == nil {
+		t.Fatal("expected a tampered ciphertext to fail authentication")
+	}
+
+	if _, err := encryptor.DecryptBytes(buf, key); err == nil {
+		t.Fatal("expected tampered bytes to fail authentication")
+	}
+}
+
+func TestAesKeySizes(t *testing.T) {
+	encryptor := newAesEncryptor(false)
+	tests := []struct {
+		name string
+		key  []byte
+		err  error
+	}{
+		{name: "short", key: []byte("0123456789"), err: ErrInvalidKeySize},
+		{name: "aes-128", key: []byte("0123456789abcdef")},
+		{name: "aes-192", key: []byte("0123456789abcdef01234567")},
+		{name: "aes-256", key: []byte("0123456789abcdef0123456789abcdef")},
+		{name: "long", key: []byte("0123456789abcdef0123456789abcdef0"), err: ErrInvalidKeySize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := encryptor.Encrypt("secret", tt.key)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if tt.err != nil {
+				return
+			}
+
+			decrypted, err := encryptor.Decrypt(encrypted, tt.key)
+			if err != nil || decrypted != "secret" {
+				t.Fatalf("expected a round trip, got %q, %v", decrypted, err)
+			}
+		})
+	}
+}
+
+func TestAesDecryptLegacyKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+	}{
+		{name: "padded", key: []byte("0123456789")},
+		{name: "padded aes-128", key: []byte("0123456789abcdef")},
+		{name: "truncated", key: []byte("0123456789abcdef0123456789abcdef01234567")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := aes.NewCipher(legacyAesKey(tt.key))
+			if err != nil {
+				t.Fatalf("could not create a cipher: %v", err)
+			}
+
+			gcm, err := cipher.NewGCM(c)
+			if err != nil {
+				t.Fatalf("could not create gcm: %v", err)
+			}
+
+			legacy, err := sealAEAD(gcm, []byte("secret"))
+			if err != nil {
+				t.Fatalf("could not encrypt: %v", err)
+			}
+
+			encoded := base64.StdEncoding.EncodeToString(legacy)
+			if _, err := newAesEncryptor(false).Decrypt(encoded, tt.key); err == nil {
+				t.Fatal("expected a legacy ciphertext to fail without legacy keys enabled")
+			}
+
+			decrypted, err := newAesEncryptor(true).Decrypt(encoded, tt.key)
+			if err != nil || decrypted != "secret" {
+				t.Fatalf("expected a legacy ciphertext to decrypt with a %d byte key, got %q, %v", len(tt.key), decrypted, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	_argon2SaltLength = 16
+	_argon2KeyLength  = 32
+	// _argon2MaxKeyLength caps key length parsed from encoded hashes.
+	_argon2MaxKeyLength = 128
+	// _argon2MaxCostFactor caps cost parameters parsed from encoded hashes
+	// relative to the configured ones.
+	_argon2MaxCostFactor = 4
+)
+
+// argon2Hasher is an Argon2id Hasher implementation
+type argon2Hasher struct {
+	time        uint32
+	memory      uint32
+	parallelism uint8
+}
+
+// A Hasher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns an Argon2id Hasher compliant implementation with the given
+// time (iterations), memory (KiB) and parallelism (threads) parameters.
+// Zero parameters fall back to defaults.
+func newArgon2Hasher(time, memory uint32, parallelism uint8) Hasher {
+	if time == 0 {
+		time = 1
+	}
+
+	if memory == 0 {
+		memory = 64 * 1024
+	}
+
+	if parallelism == 0 {
+		parallelism = 4
+	}
+
+	return argon2Hasher{
+		time:        time,
+		memory:      memory,
+		parallelism: parallelism,
+	}
+}
+
+// Hash transforms plaintext into a salted Argon2id hash encoded as
+// $argon2id$v=19$m=<memory>,t=<time>,p=<parallelism>$<salt>$<hash>.
+//
+// A successful Hash return a non-empty string.
+func (h argon2Hasher) Hash(text string) string {
+	salt := make([]byte, _argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return ""
+	}
+
+	key := argon2.IDKey([]byte(text), salt, h.time, h.memory, h.parallelism, _argon2KeyLength)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.time, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// Compare checks whether the encoded Argon2id hash matches the plaintext.
+// Hash parameters are parsed from the encoded string. Hashes with parameters
+// exceeding the configured ones more than _argon2MaxCostFactor times are
+// rejected to keep tampered hashes from exhausting memory and CPU.
+func (h argon2Hasher) Compare(hash, text string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var (
+		memory, time uint32
+		parallelism  uint8
+	)
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &parallelism); err != nil {
+		return false
+	}
+
+	if memory == 0 || time == 0 || parallelism == 0 {
+		return false
+	}
+
+	if uint64(memory) > uint64(h.memory)*_argon2MaxCostFactor ||
+		uint64(time) > uint64(h.time)*_argon2MaxCostFactor ||
+		uint64(parallelism) > uint64(h.parallelism)*_argon2MaxCostFactor {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 || len(key) > _argon2MaxKeyLength {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(text), salt, time, memory, parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
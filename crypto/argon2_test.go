@@ -0,0 +1,72 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArgon2Compare(t *testing.T) {
+	hasher := newArgon2Hasher(1, 8*1024, 1)
+	hash := hasher.Hash("password")
+	if hash == "" {
+		t.Fatal("expected a non-empty hash")
+	}
+
+	if !hasher.Compare(hash, "password") {
+		t.Fatal("expected a hash to match its plaintext")
+	}
+
+	if hasher.Compare(hash, "another") {
+		t.Fatal("expected a hash not to match another plaintext")
+	}
+}
+
+func TestArgon2CompareTampered(t *testing.T) {
+	hasher := newArgon2Hasher(1, 8*1024, 1)
+	hash := hasher.Hash("password")
+	parts := strings.Split(hash, "$")
+
+	tampered := map[string]func([]string){
+		"algorithm":   func(p []string) { p[1] = "argon2i" },
+		"version":     func(p []string) { p[2] = "v=16" },
+		"parameters":  func(p []string) { p[3] = "m=8192,t=2,p=1" },
+		"huge memory": func(p []string) { p[3] = "m=4294967295,t=1,p=1" },
+		"huge time":   func(p []string) { p[3] = "m=8192,t=4294967295,p=1" },
+		"huge key":    func(p []string) { p[5] = strings.Repeat("A", 1024) },
+		"salt":        func(p []string) { p[4] = "!" },
+		"key":         func(p []string) { p[5] = p[5][1:] + "A" },
+		"empty":       func(p []string) { p[5] = "" },
+	}
+
+	for name, tamper := range tampered {
+		t.Run(name, func(t *testing.T) {
+			p := append([]string(nil), parts...)
+			tamper(p)
+			if hasher.Compare(strings.Join(p, "$"), "password") {
+				t.Fatal("expected a tampered hash to be rejected")
+			}
+		})
+	}
+
+	if hasher.Compare("not a hash", "password") {
+		t.Fatal("expected a malformed hash to be rejected")
+	}
+}
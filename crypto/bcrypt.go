@@ -0,0 +1,60 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptHasher is a bcrypt Hasher implementation
+type bcryptHasher struct{}
+
+// A Hasher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns a bcrypt Hasher compliant implementation.
+func newBcryptHasher() Hasher {
+	return bcryptHasher{}
+}
+
+// Hash transforms plaintext into a salted bcrypt hash.
+// Every call produces a different hash, so Compare should be used
+// to check plaintexts.
+//
+// A successful Hash return a non-empty string. Plaintexts longer than
+// 72 bytes are rejected by bcrypt and produce an empty string.
+func (h bcryptHasher) Hash(text string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(text), bcrypt.DefaultCost)
+	if err != nil {
+		return ""
+	}
+
+	return string(hash)
+}
+
+// Compare checks whether the bcrypt hash matches the plaintext.
+func (h bcryptHasher) Compare(hash, text string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(text)) == nil
+}
@@ -0,0 +1,141 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/cipher"
+	"encoding/base64"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// A chachaEncryptor provides a ChaCha20-Poly1305 encryption implementation. This structure is expected to be
+// initialized automatically by fx via yaml and env.
+//
+// Unlike AES, keys are neither derived nor padded: anything but a 32 bytes key
+// fails with ErrInvalidKeySize.
+type chachaEncryptor struct{}
+
+// A ChaCha20-Poly1305 encryptor constructor. Called internally and automatically by fx and
+// bootstrapper with based on specific encryptor type.
+//
+// Returns a ChaCha20-Poly1305 encryptor implementation.
+func newChachaEncryptor() Encryptor {
+	return chachaEncryptor{}
+}
+
+// Encrypt transforms plaintext into an encrypted one with the given 32 bytes key.
+// It returns base64 encoded encrypted text and the first encountered error.
+//
+// A successful Encrypt returns encrypted text and err == nil.
+func (e chachaEncryptor) Encrypt(text string, key []byte) (string, error) {
+	result, err := e.EncryptBytes([]byte(text), key)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(result), nil
+}
+
+// Decrypt transforms base64 encoded encrypted text into a decrypted one with the given 32 bytes key.
+// It returns decrypted text and the first encountered error.
+//
+// A successful Decrypt returns decrypted text and err == nil.
+func (e chachaEncryptor) Decrypt(text string, key []byte) (string, error) {
+	buf, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := e.DecryptBytes(buf, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// EncryptBytes transforms data into a nonce-prefixed ciphertext with the given 32 bytes key.
+// It returns raw encrypted bytes and the first encountered error.
+//
+// A successful EncryptBytes returns encrypted bytes and err == nil.
+func (e chachaEncryptor) EncryptBytes(data, key []byte) ([]byte, error) {
+	aead, err := newChacha(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealAEAD(aead, data)
+}
+
+// DecryptBytes transforms a nonce-prefixed ciphertext into plain data with the given 32 bytes key.
+// It returns decrypted bytes and the first encountered error.
+//
+// A successful DecryptBytes returns decrypted bytes and err == nil.
+func (e chachaEncryptor) DecryptBytes(data, key []byte) ([]byte, error) {
+	aead, err := newChacha(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return openAEAD(aead, data)
+}
+
+// EncryptStream encrypts src into dst in chunks with the given 32 bytes key without buffering
+// the whole input. Every chunk is sealed with its own nonce.
+// It returns the first encountered read, write or encryption error.
+//
+// A successful EncryptStream returns err == nil.
+func (e chachaEncryptor) EncryptStream(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newChacha(key)
+	if err != nil {
+		return err
+	}
+
+	return encryptStream(aead, dst, src)
+}
+
+// DecryptStream decrypts src produced by EncryptStream into dst with the given 32 bytes key.
+// It returns the first encountered read, write or decryption error.
+//
+// A successful DecryptStream returns err == nil.
+func (e chachaEncryptor) DecryptStream(dst io.Writer, src io.Reader, key []byte) error {
+	aead, err := newChacha(key)
+	if err != nil {
+		return err
+	}
+
+	return decryptStream(aead, dst, src)
+}
+
+// newChacha validates the key and builds a ChaCha20-Poly1305 cipher.
+func newChacha(key []byte) (cipher.AEAD, error) {
+	if len(key) != chacha20poly1305.KeySize {
+		return nil, ErrInvalidKeySize
+	}
+
+	return chacha20poly1305.New(key)
+}
@@ -0,0 +1,100 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestChachaRoundTrip(t *testing.T) {
+	encryptor := newChachaEncryptor()
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypted, err := encryptor.Encrypt("secret", key)
+	if err != nil {
+		t.Fatalf("could not encrypt: %v", err)
+	}
+
+	if decrypted, err := encryptor.Decrypt(encrypted, key); err != nil || decrypted != "secret" {
+		t.Fatalf("expected a round trip, got %q, %v", decrypted, err)
+	}
+
+	data := []byte("binary\x00data")
+	buf, err := encryptor.EncryptBytes(data, key)
+	if err != nil {
+		t.Fatalf("could not encrypt bytes: %v", err)
+	}
+
+	if decrypted, err := encryptor.DecryptBytes(buf, key); err != nil || !bytes.Equal(decrypted, data) {
+		t.Fatalf("expected a bytes round trip, got %q, %v", decrypted, err)
+	}
+}
+
+func TestChachaDecryptTampered(t *testing.T) {
+	encryptor := newChachaEncryptor()
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypted, err := encryptor.Encrypt("secret", key)
+	if err != nil {
+		t.Fatalf("could not encrypt: %v", err)
+	}
+
+	buf, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("could not decode ciphertext: %v", err)
+	}
+
+	buf[len(buf)-1] ^= 0x01
+	if _, err := encryptor.DecryptBytes(buf, key); err == nil {
+		t.Fatal("expected a tampered ciphertext to fail authentication")
+	}
+
+	if _, err := encryptor.DecryptBytes(buf[:4], key); err == nil {
+		t.Fatal("expected a truncated ciphertext to fail")
+	}
+}
+
+func TestChachaKeySizes(t *testing.T) {
+	encryptor := newChachaEncryptor()
+	tests := []struct {
+		name string
+		key  []byte
+		err  error
+	}{
+		{name: "aes-128 sized", key: []byte("0123456789abcdef"), err: ErrInvalidKeySize},
+		{name: "aes-192 sized", key: []byte("0123456789abcdef01234567"), err: ErrInvalidKeySize},
+		{name: "32 bytes", key: []byte("0123456789abcdef0123456789abcdef")},
+		{name: "long", key: []byte("0123456789abcdef0123456789abcdef0"), err: ErrInvalidKeySize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encryptor.Encrypt("secret", tt.key); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if _, err := encryptor.DecryptBytes(make([]byte, 64), tt.key); tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v on decryption, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// BuildDocumentClaims takes an arbitrary payload and wraps it with exp, iat and nbf
+// registered claims based on the given ttl.
+// Payloads encoded as JSON objects are merged into the top level claims (registered claims
+// take precedence), other payloads (including nil) are put under the "payload" key.
+//
+// It returns claims ready to be passed to JwtManager.Sign.
+func BuildDocumentClaims(payload any, ttl time.Duration) jwt.Claims {
+	var claims jwt.MapClaims
+	if buf, err := json.Marshal(payload); err != nil || json.Unmarshal(buf, &claims) != nil || claims == nil {
+		claims = jwt.MapClaims{"payload": payload}
+	}
+
+	now := time.Now()
+	claims["iat"] = jwt.NewNumericDate(now)
+	claims["nbf"] = jwt.NewNumericDate(now)
+	claims["exp"] = jwt.NewNumericDate(now.Add(ttl))
+
+	return claims
+}
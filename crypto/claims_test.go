@@ -0,0 +1,76 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestBuildDocumentClaims(t *testing.T) {
+	claims := BuildDocumentClaims(map[string]any{"key": "doc", "exp": 1}, time.Minute).(jwt.MapClaims)
+	if claims["key"] != "doc" {
+		t.Fatalf("expected an object payload to be merged, got %v", claims)
+	}
+
+	if exp, ok := claims["exp"].(*jwt.NumericDate); !ok || time.Until(exp.Time) <= 0 {
+		t.Fatalf("expected the registered exp claim to take precedence, got %v", claims["exp"])
+	}
+}
+
+func TestBuildDocumentClaimsNonObjectPayload(t *testing.T) {
+	for _, payload := range []any{nil, "document", 42, []string{"a", "b"}} {
+		claims := BuildDocumentClaims(payload, time.Minute).(jwt.MapClaims)
+		if _, ok := claims["payload"]; !ok {
+			t.Fatalf("expected %v to be put under the payload key, got %v", payload, claims)
+		}
+
+		if _, ok := claims["exp"]; !ok {
+			t.Fatalf("expected registered claims for %v", payload)
+		}
+	}
+}
+
+func TestBuildDocumentClaimsExpired(t *testing.T) {
+	manager := newOnlyofficeJwtManager("")
+	token, err := manager.Sign("secret", BuildDocumentClaims(map[string]any{"key": "doc"}, -time.Minute))
+	if err != nil {
+		t.Fatalf("could not sign claims: %v", err)
+	}
+
+	var body struct {
+		Key string `json:"key"`
+	}
+
+	if err := manager.Verify("secret", token, &body); !errors.Is(err, jwt.ErrTokenExpired) {
+		t.Fatalf("expected an expired token to be rejected, got %v", err)
+	}
+
+	token, err = manager.Sign("secret", BuildDocumentClaims(map[string]any{"key": "doc"}, time.Minute))
+	if err != nil {
+		t.Fatalf("could not sign claims: %v", err)
+	}
+
+	if err := manager.Verify("secret", token, &body); err != nil || body.Key != "doc" {
+		t.Fatalf("expected a valid token to be verified, got %q, %v", body.Key, err)
+	}
+}
@@ -25,15 +25,27 @@
 package crypto
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // An Encryptor provides basic contract for encryption types.
 // The implementation structure is expected to be initialized automatically by fx and bootstrapper.
+// Key sizes depend on the implementation: AES GCM accepts 16, 24 or 32 bytes keys,
+// ChaCha20-Poly1305 accepts 32 bytes keys only. Other sizes fail with ErrInvalidKeySize.
 type Encryptor interface {
 	Encrypt(text string, key []byte) (string, error)
 	Decrypt(ciphertext string, key []byte) (string, error)
+	EncryptBytes(data, key []byte) ([]byte, error)
+	DecryptBytes(data, key []byte) ([]byte, error)
+	EncryptStream(dst io.Writer, src io.Reader, key []byte) error
+	DecryptStream(dst io.Writer, src io.Reader, key []byte) error
 }
 
 // An Encryptor constructor. Called automatically by fx and
@@ -44,9 +56,11 @@ type Encryptor interface {
 func NewEncryptor(config *config.CryptoConfig) Encryptor {
 	switch config.Crypto.EncryptorType {
 	case 1:
-		return newAesEncryptor()
+		return newAesEncryptor(config.Crypto.AesLegacyKeys)
+	case 2:
+		return newChachaEncryptor()
 	default:
-		return newAesEncryptor()
+		return newAesEncryptor(config.Crypto.AesLegacyKeys)
 	}
 }
 
@@ -55,6 +69,8 @@ func NewEncryptor(config *config.CryptoConfig) Encryptor {
 type JwtManager interface {
 	Sign(secret string, payload jwt.Claims) (string, error)
 	Verify(secret, jwtToken string, body interface{}) error
+	VerifyRequest(r *http.Request, secret string, body interface{}) error
+	VerifyWithKeys(jwtToken string, secrets []string, body interface{}) error
 }
 
 // A JwtManager constructor. Called automatically by fx and
@@ -65,9 +81,11 @@ type JwtManager interface {
 func NewJwtManager(config *config.CryptoConfig) JwtManager {
 	switch config.Crypto.JwtManagerType {
 	case 1:
-		return newOnlyofficeJwtManager()
+		return newOnlyofficeJwtManager(config.Crypto.JwtHeader)
+	case 2:
+		return newAsymmetricJwtManager(config.Crypto.JwtHeader)
 	default:
-		return newOnlyofficeJwtManager()
+		return newOnlyofficeJwtManager(config.Crypto.JwtHeader)
 	}
 }
 
@@ -75,32 +93,106 @@ func NewJwtManager(config *config.CryptoConfig) JwtManager {
 // The implementation structure is expected to be intialized automatically by fx and bootstrapper.
 type Hasher interface {
 	Hash(text string) string
+	Compare(hash, text string) bool
 }
 
 // A Hasher constructor. Called automatically by fx and
 // bootstrapper.
 //
 // Returns a hasher implementation based on configuration.
-// By default returns an md5 hasher.
+// By default returns an md5 hasher. Keyed hashing (type 5) is
+// provided by NewKeyedHasher, so NewHasher keeps the default.
 func NewHasher(config *config.CryptoConfig) Hasher {
 	switch config.Crypto.HasherType {
 	case 1:
 		return newMD5Hasher()
+	case 2:
+		return newBcryptHasher()
+	case 3:
+		return newSHA256Hasher()
+	case 4:
+		return newArgon2Hasher(
+			config.Crypto.Argon2Time, config.Crypto.Argon2Memory,
+			config.Crypto.Argon2Parallelism,
+		)
 	default:
 		return newMD5Hasher()
 	}
 }
 
+// A KeyedHasher provides basic contract for generating keyed hash (i.e. webhook signatures).
+// The implementation structure is expected to be intialized automatically by fx and bootstrapper.
+type KeyedHasher interface {
+	Hash(text string, key []byte) string
+	Compare(hash, text string, key []byte) bool
+}
+
+// A KeyedHasher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns an HMAC-SHA256 keyed hasher implementation.
+func NewKeyedHasher() KeyedHasher {
+	return newHMACSHA256Hasher()
+}
+
 // A StateGenerator provides basic contract for generating a cryptographic state.
 // The implementation structure is expected to be intialized automatically by fx and bootstrapper.
 type StateGenerator interface {
 	GenerateState(secret string) (string, error)
+	VerifyState(secret, state string, maxAge time.Duration) (bool, error)
 }
 
 // A StateGenerator constructor. Called automatically by fx and
 // bootstrapper.
 //
 // Returns a state generator implementation based on configuration.
-func NewStateGenerator() StateGenerator {
-	return newStateGenerator()
+// By default returns an HMAC state generator.
+func NewStateGenerator(config *config.CryptoConfig) StateGenerator {
+	switch config.Crypto.StateGeneratorType {
+	case 1:
+		return newStateGenerator()
+	case 2:
+		return newEd25519StateGenerator()
+	default:
+		return newStateGenerator()
+	}
+}
+
+// requestToken extracts a jwt from the Authorization: Bearer header, the custom
+// header or the token query parameter, in that order.
+func requestToken(r *http.Request, header string) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if token = strings.TrimSpace(token); token != "" {
+			return token
+		}
+	}
+
+	if header != "" {
+		token := strings.TrimSpace(r.Header.Get(header))
+		if token != "" {
+			return strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+		}
+	}
+
+	return r.URL.Query().Get("token")
+}
+
+// verifyWithKeys tries to verify a jwt with every secret until one succeeds.
+// It returns all the verification errors joined if none does.
+func verifyWithKeys(manager JwtManager, jwtToken string, secrets []string, body interface{}) error {
+	if len(secrets) == 0 {
+		return ErrJwtManagerEmptySecret
+	}
+
+	errs := make([]error, 0, len(secrets))
+	for _, secret := range secrets {
+		err := manager.Verify(secret, jwtToken, body)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,57 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// hmacSHA256Hasher is an HMAC-SHA256 KeyedHasher implementation
+type hmacSHA256Hasher struct{}
+
+// A KeyedHasher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns an HMAC-SHA256 KeyedHasher compliant implementation.
+func newHMACSHA256Hasher() KeyedHasher {
+	return hmacSHA256Hasher{}
+}
+
+// Hash transforms plaintext into a keyed hash.
+// It returns a hex encoded hash string.
+//
+// A successful Hash return a non-empty string.
+func (h hmacSHA256Hasher) Hash(text string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(text))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Compare checks whether the hash matches the plaintext and key.
+func (h hmacSHA256Hasher) Compare(hash, text string, key []byte) bool {
+	return hmac.Equal([]byte(h.Hash(text, key)), []byte(hash))
+}
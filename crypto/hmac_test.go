@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+)
+
+func TestHMACSHA256HasherRFC4231(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+		text string
+		hash string
+	}{
+		{
+			"test case 1", bytes.Repeat([]byte{0x0b}, 20), "Hi There",
+			"b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+		},
+		{
+			"test case 2", []byte("Jefe"), "what do ya want for nothing?",
+			"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+		{
+			"test case 3", bytes.Repeat([]byte{0xaa}, 20), strings.Repeat("\xdd", 50),
+			"773ea91e36800e46854db8ebd09181a72959098b3ef8c122d9635514ced565fe",
+		},
+		{
+			"test case 6", bytes.Repeat([]byte{0xaa}, 131), "Test Using Larger Than Block-Size Key - Hash Key First",
+			"60e431591ee0b67f0d8a26aacbf5b77f8e0bc6213728c5140546040f0ee37f54",
+		},
+	}
+
+	hasher := NewKeyedHasher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hash := hasher.Hash(tt.text, tt.key); hash != tt.hash {
+				t.Fatalf("expected %s, got %s", tt.hash, hash)
+			}
+
+			if !hasher.Compare(tt.hash, tt.text, tt.key) {
+				t.Fatal("expected the hash to match")
+			}
+
+			if hasher.Compare(tt.hash, tt.text, []byte("wrong")) {
+				t.Fatal("expected a hash with another key not to match")
+			}
+		})
+	}
+}
+
+func TestNewHasherKeyedTypeDefault(t *testing.T) {
+	var cryptoConfig config.CryptoConfig
+	cryptoConfig.Crypto.HasherType = 5
+	if _, ok := NewHasher(&cryptoConfig).(md5Hasher); !ok {
+		t.Fatal("expected the keyed hasher type to keep the md5 default")
+	}
+}
@@ -26,6 +26,7 @@ package crypto
 
 import (
 	"errors"
+	"net/http"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/mitchellh/mapstructure"
@@ -39,15 +40,17 @@ var ErrJwtManagerInvalidSigningMethod = errors.New("unexpected jwt signing metho
 var ErrJwtManagerCastOrInvalidToken = errors.New("could not cast claims or invalid jwt")
 
 // onlyofficeJwtManager is a basic JwtManager implementation
-type onlyofficeJwtManager struct{}
+type onlyofficeJwtManager struct {
+	header string
+}
 
 // A JwtManager constructor. Called automatically by fx and
 // bootstrapper.
 //
 // Returns a JwtManager compliant implementation based
 // on cache configuration.
-func newOnlyofficeJwtManager() JwtManager {
-	return onlyofficeJwtManager{}
+func newOnlyofficeJwtManager(header string) JwtManager {
+	return onlyofficeJwtManager{header: header}
 }
 
 // Sign converts jwt payload into a string by signing the payload with a secret.
@@ -66,6 +69,8 @@ func (j onlyofficeJwtManager) Sign(secret string, payload jwt.Claims) (string, e
 }
 
 // Verify converts and verifies with a secret a jwt into a structure.
+// Expired (exp) and not yet valid (nbf) tokens are rejected. The body structure
+// must not declare its own exp, iat or nbf fields, otherwise registered claims get shadowed.
 // It populates structure fields and returns the first encountered error.
 //
 // A successful Verify returns err == nil.
@@ -100,3 +105,21 @@ func (j onlyofficeJwtManager) Verify(secret, jwtToken string, body interface{})
 		return mapstructure.Decode(claims, body)
 	}
 }
+
+// VerifyRequest extracts a jwt from the request's Authorization: Bearer header,
+// the configured custom header or the token query parameter (in that order)
+// and verifies it with a secret.
+//
+// A successful VerifyRequest returns err == nil.
+func (j onlyofficeJwtManager) VerifyRequest(r *http.Request, secret string, body interface{}) error {
+	return j.Verify(secret, requestToken(r, j.header), body)
+}
+
+// VerifyWithKeys verifies a jwt with a list of secrets (i.e. the current and
+// the previous one during rotation). The first successful verification populates
+// the body. It returns all the verification errors joined otherwise.
+//
+// A successful VerifyWithKeys returns err == nil.
+func (j onlyofficeJwtManager) VerifyWithKeys(jwtToken string, secrets []string, body interface{}) error {
+	return verifyWithKeys(j, jwtToken, secrets, body)
+}
@@ -0,0 +1,151 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mitchellh/mapstructure"
+)
+
+var ErrJwtManagerInvalidKey = errors.New("could not parse a PEM encoded RSA or EC key")
+
+// asymmetricJwtManager is an RS256/ES256 JwtManager implementation.
+// Secrets are expected to be PEM encoded private keys for signing and
+// PEM encoded public keys for verification.
+type asymmetricJwtManager struct {
+	header string
+}
+
+// A JwtManager constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns a JwtManager compliant implementation based
+// on RSA and EC keys.
+func newAsymmetricJwtManager(header string) JwtManager {
+	return asymmetricJwtManager{header: header}
+}
+
+// Sign converts jwt payload into a string by signing the payload with a PEM encoded
+// RSA (RS256) or EC (ES256) private key.
+// It returns a signed token and the first encountered error.
+//
+// A successful Sign returns a jwt and err == nil.
+func (j asymmetricJwtManager) Sign(secret string, payload jwt.Claims) (string, error) {
+	if secret == "" {
+		return "", ErrJwtManagerEmptySecret
+	}
+
+	var (
+		method jwt.SigningMethod
+		key    interface{}
+	)
+
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(secret)); err == nil {
+		method, key = jwt.SigningMethodRS256, rsaKey
+	} else if ecKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(secret)); err == nil {
+		method, key = jwt.SigningMethodES256, ecKey
+	} else {
+		return "", ErrJwtManagerInvalidKey
+	}
+
+	ss, err := jwt.NewWithClaims(method, payload).SignedString(key)
+	if err != nil {
+		return "", ErrJwtManagerSigning
+	}
+
+	return ss, nil
+}
+
+// Verify converts and verifies with a PEM encoded RSA or EC public key a jwt into a structure.
+// Tokens signed with an algorithm other than the one matching the key type are rejected.
+// Expired (exp) and not yet valid (nbf) tokens are rejected.
+// It populates structure fields and returns the first encountered error.
+//
+// A successful Verify returns err == nil.
+func (j asymmetricJwtManager) Verify(secret, jwtToken string, body interface{}) error {
+	if secret == "" {
+		return ErrJwtManagerEmptySecret
+	}
+
+	if jwtToken == "" {
+		return ErrJwtManagerEmptyToken
+	}
+
+	if body == nil {
+		return ErrJwtManagerEmptyDecodingBody
+	}
+
+	var (
+		method jwt.SigningMethod
+		key    interface{}
+	)
+
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(secret)); err == nil {
+		method, key = jwt.SigningMethodRS256, rsaKey
+	} else if ecKey, err := jwt.ParseECPublicKeyFromPEM([]byte(secret)); err == nil {
+		method, key = jwt.SigningMethodES256, ecKey
+	} else {
+		return ErrJwtManagerInvalidKey
+	}
+
+	token, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != method.Alg() {
+			return nil, ErrJwtManagerInvalidSigningMethod
+		}
+
+		return key, nil
+	}, jwt.WithValidMethods([]string{method.Alg()}))
+
+	if err != nil {
+		return err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); !ok || !token.Valid {
+		return ErrJwtManagerCastOrInvalidToken
+	} else {
+		return mapstructure.Decode(claims, body)
+	}
+}
+
+// VerifyRequest extracts a jwt from the request's Authorization: Bearer header,
+// the configured custom header or the token query parameter (in that order)
+// and verifies it with a PEM encoded public key.
+//
+// A successful VerifyRequest returns err == nil.
+func (j asymmetricJwtManager) VerifyRequest(r *http.Request, secret string, body interface{}) error {
+	return j.Verify(secret, requestToken(r, j.header), body)
+}
+
+// VerifyWithKeys verifies a jwt with a list of PEM encoded public keys (i.e. the current and
+// the previous one during rotation). The first successful verification populates
+// the body. It returns all the verification errors joined otherwise.
+//
+// A successful VerifyWithKeys returns err == nil.
+func (j asymmetricJwtManager) VerifyWithKeys(jwtToken string, secrets []string, body interface{}) error {
+	return verifyWithKeys(j, jwtToken, secrets, body)
+}
@@ -0,0 +1,143 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestAsymmetricKeys returns PEM encoded private and public keys.
+func newTestAsymmetricKeys(t *testing.T, privateKey crypto.Signer) (string, string) {
+	t.Helper()
+	private, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("could not marshal a private key: %v", err)
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		t.Fatalf("could not marshal a public key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+}
+
+func newTestRSAKeys(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate an rsa key: %v", err)
+	}
+
+	return newTestAsymmetricKeys(t, key)
+}
+
+func newTestECKeys(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate an ec key: %v", err)
+	}
+
+	return newTestAsymmetricKeys(t, key)
+}
+
+func TestAsymmetricJwtManagerRoundTrip(t *testing.T) {
+	manager := newAsymmetricJwtManager("AuthorizationJwt")
+	tests := []struct {
+		name string
+		keys func(t *testing.T) (string, string)
+		alg  string
+	}{
+		{name: "rs256", keys: newTestRSAKeys, alg: "RS256"},
+		{name: "es256", keys: newTestECKeys, alg: "ES256"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			private, public := tt.keys(t)
+			token := signTestToken(t, manager, private, "doc")
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			if err != nil || parsed.Method.Alg() != tt.alg {
+				t.Fatalf("expected a %s token, got %v", tt.alg, err)
+			}
+
+			var body jwtTestBody
+			if err := manager.Verify(public, token, &body); err != nil {
+				t.Fatalf("could not verify a token: %v", err)
+			}
+
+			if body.Key != "doc" {
+				t.Fatalf("expected the doc key, got %s", body.Key)
+			}
+		})
+	}
+}
+
+func TestAsymmetricJwtManagerRejectsWrongKey(t *testing.T) {
+	manager := newAsymmetricJwtManager("AuthorizationJwt")
+	rsaPrivate, _ := newTestRSAKeys(t)
+	_, otherPublic := newTestRSAKeys(t)
+	_, ecPublic := newTestECKeys(t)
+	token := signTestToken(t, manager, rsaPrivate, "doc")
+
+	if err := manager.Verify(otherPublic, token, &jwtTestBody{}); err == nil {
+		t.Fatal("expected a token signed with another key to be rejected")
+	}
+
+	if err := manager.Verify(ecPublic, token, &jwtTestBody{}); err == nil {
+		t.Fatal("expected an RS256 token to be rejected with an EC key")
+	}
+}
+
+func TestAsymmetricJwtManagerRejectsHMACWithPublicKey(t *testing.T) {
+	manager := newAsymmetricJwtManager("AuthorizationJwt")
+	for name, keys := range map[string]func(t *testing.T) (string, string){"rsa": newTestRSAKeys, "ec": newTestECKeys} {
+		t.Run(name, func(t *testing.T) {
+			_, public := keys(t)
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"key": "doc"}).
+				SignedString([]byte(public))
+			if err != nil {
+				t.Fatalf("could not sign a token: %v", err)
+			}
+
+			var body jwtTestBody
+			err = manager.Verify(public, token, &body)
+			if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+				t.Fatalf("expected an HS256 token signed with the public key to be rejected, got %v", err)
+			}
+
+			if body.Key != "" {
+				t.Fatalf("expected the body not to be populated, got %s", body.Key)
+			}
+		})
+	}
+}
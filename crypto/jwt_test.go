@@ -0,0 +1,110 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtTestBody struct {
+	Key string `mapstructure:"key"`
+}
+
+func signTestToken(t *testing.T, manager JwtManager, secret, key string) string {
+	t.Helper()
+	token, err := manager.Sign(secret, jwt.MapClaims{"key": key})
+	if err != nil {
+		t.Fatalf("could not sign a token: %v", err)
+	}
+
+	return token
+}
+
+func TestJwtManagerVerifyRequest(t *testing.T) {
+	manager := newOnlyofficeJwtManager("AuthorizationJwt")
+	bearer := signTestToken(t, manager, "secret", "bearer")
+	header := signTestToken(t, manager, "secret", "header")
+	query := signTestToken(t, manager, "secret", "query")
+
+	tests := []struct {
+		name    string
+		prepare func(r *http.Request)
+		key     string
+	}{
+		{"bearer", func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+bearer) }, "bearer"},
+		{"custom header", func(r *http.Request) { r.Header.Set("AuthorizationJwt", "Bearer "+header) }, "header"},
+		{"query", func(r *http.Request) {}, "query"},
+		{"bearer precedence", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+bearer)
+			r.Header.Set("AuthorizationJwt", header)
+		}, "bearer"},
+		{"custom header precedence", func(r *http.Request) { r.Header.Set("AuthorizationJwt", header) }, "header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/callback?token="+query, nil)
+			tt.prepare(r)
+
+			var body jwtTestBody
+			if err := manager.VerifyRequest(r, "secret", &body); err != nil {
+				t.Fatalf("could not verify a request: %v", err)
+			}
+
+			if body.Key != tt.key {
+				t.Fatalf("expected the %s token, got %s", tt.key, body.Key)
+			}
+		})
+	}
+}
+
+func TestJwtManagerVerifyRequestMissingToken(t *testing.T) {
+	manager := newOnlyofficeJwtManager("AuthorizationJwt")
+	r := httptest.NewRequest(http.MethodPost, "/callback", nil)
+	if err := manager.VerifyRequest(r, "secret", &jwtTestBody{}); !errors.Is(err, ErrJwtManagerEmptyToken) {
+		t.Fatalf("expected an empty token error, got %v", err)
+	}
+}
+
+func TestJwtManagerVerifyWithKeys(t *testing.T) {
+	manager := newOnlyofficeJwtManager("")
+	token := signTestToken(t, manager, "previous", "rotated")
+
+	var body jwtTestBody
+	if err := manager.VerifyWithKeys(token, []string{"current", "previous"}, &body); err != nil {
+		t.Fatalf("expected a token signed with the previous secret to be verified, got %v", err)
+	}
+
+	if body.Key != "rotated" {
+		t.Fatalf("expected the token body, got %s", body.Key)
+	}
+
+	if err := manager.VerifyWithKeys(token, []string{"current", "next"}, &body); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Fatalf("expected joined signature errors, got %v", err)
+	}
+
+	if err := manager.VerifyWithKeys(token, nil, &body); !errors.Is(err, ErrJwtManagerEmptySecret) {
+		t.Fatalf("expected an empty secret error, got %v", err)
+	}
+}
@@ -26,6 +26,7 @@ package crypto
 
 import (
 	"crypto/md5"
+	"crypto/subtle"
 	"encoding/hex"
 )
 
@@ -49,3 +50,8 @@ func (h md5Hasher) Hash(text string) string {
 	hash := md5.Sum([]byte(text))
 	return hex.EncodeToString(hash[:])
 }
+
+// Compare checks whether the hash matches the plaintext.
+func (h md5Hasher) Compare(hash, text string) bool {
+	return subtle.ConstantTimeCompare([]byte(h.Hash(text)), []byte(hash)) == 1
+}
@@ -0,0 +1,56 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// sha256Hasher is a SHA-256 Hasher implementation
+type sha256Hasher struct{}
+
+// A Hasher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Returns a SHA-256 Hasher compliant implementation.
+func newSHA256Hasher() Hasher {
+	return sha256Hasher{}
+}
+
+// Hash transforms plaintext into a hashed text.
+// It returns a hex encoded hash string.
+//
+// A successful Hash return a non-empty string.
+func (h sha256Hasher) Hash(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
+
+// Compare checks whether the hash matches the plaintext.
+func (h sha256Hasher) Compare(hash, text string) bool {
+	return subtle.ConstantTimeCompare([]byte(h.Hash(text)), []byte(hash)) == 1
+}
@@ -31,7 +31,9 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // stateGenerator is a basic StateGenerator implementation.
@@ -43,21 +45,73 @@ func newStateGenerator() StateGenerator {
 }
 
 // GenerateState takes a secret and generates an oauth2 state.
+// The state consists of a signature, a random nonce and a creation timestamp (unix seconds)
+// joined with dots. The signature covers both the nonce and the timestamp.
 // It returns a newly generated state and the first encountered error.
 //
 // A successful GenerateState returns a state and err == nil.
 func (sg stateGenerator) GenerateState(secret string) (string, error) {
-	ts, err := randomHex(64)
+	nonce, err := randomHex(64)
 	if err != nil {
 		return "", err
 	}
 
-	hmac, err := hmacBase64(ts, secret)
+	payload := strings.Join([]string{nonce, strconv.FormatInt(time.Now().Unix(), 10)}, ".")
+	hmac, err := hmacBase64(payload, secret)
 	if err != nil {
 		return "", err
 	}
 
-	return url.QueryEscape(strings.ReplaceAll(strings.Join([]string{hmac, ts}, "."), "+", "")), nil
+	return url.QueryEscape(strings.ReplaceAll(strings.Join([]string{hmac, payload}, "."), "+", "")), nil
+}
+
+// VerifyState takes a secret, a state generated by GenerateState and the state's max age.
+// Zero or negative maxAge disables expiration checks. Legacy states (without a timestamp)
+// are only accepted when expiration checks are disabled.
+// It returns a verification flag and the first encountered error.
+//
+// Malformed, tampered and expired states are reported as false with err == nil.
+func (sg stateGenerator) VerifyState(secret, state string, maxAge time.Duration) (bool, error) {
+	unescaped, err := url.QueryUnescape(state)
+	if err != nil {
+		return false, nil
+	}
+
+	var created int64
+	parts := strings.Split(unescaped, ".")
+	switch len(parts) {
+	case 2:
+		if maxAge > 0 {
+			return false, nil
+		}
+	case 3:
+		if created, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return false, nil
+		}
+	}
+
+	expected, err := hmacBase64(strings.Join(parts[1:], "."), secret)
+	if err != nil {
+		return false, err
+	}
+
+	if !hmac.Equal([]byte(strings.ReplaceAll(expected, "+", "")), []byte(parts[0])) {
+		return false, nil
+	}
+
+	if maxAge > 0 && time.Since(time.Unix(created, 0)) > maxAge {
+		return false, nil
+	}
+
+	return true, nil
 }
 
 // randomHex takes a buffer's length and outputs a random hex string.
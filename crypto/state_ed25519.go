@@ -0,0 +1,124 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrStateInvalidKey = errors.New("could not parse a PEM encoded Ed25519 key")
+
+// ed25519StateGenerator is an Ed25519 StateGenerator implementation.
+// Secrets are expected to be PEM encoded private keys for generation and
+// PEM encoded public keys for verification, so that verifiers only hold
+// a public key.
+type ed25519StateGenerator struct {
+}
+
+func newEd25519StateGenerator() StateGenerator {
+	return ed25519StateGenerator{}
+}
+
+// GenerateState takes a PEM encoded Ed25519 private key and generates an oauth2 state.
+// The state consists of a signature, a random nonce and a creation timestamp (unix seconds)
+// joined with dots. The signature covers both the nonce and the timestamp.
+// It returns a newly generated state and the first encountered error.
+//
+// A successful GenerateState returns a state and err == nil.
+func (sg ed25519StateGenerator) GenerateState(secret string) (string, error) {
+	key, err := jwt.ParseEdPrivateKeyFromPEM([]byte(secret))
+	if err != nil {
+		return "", ErrStateInvalidKey
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", ErrStateInvalidKey
+	}
+
+	nonce, err := randomHex(64)
+	if err != nil {
+		return "", err
+	}
+
+	payload := strings.Join([]string{nonce, strconv.FormatInt(time.Now().Unix(), 10)}, ".")
+	signature := base64.RawURLEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(payload)))
+	return url.QueryEscape(strings.Join([]string{signature, payload}, ".")), nil
+}
+
+// VerifyState takes a PEM encoded Ed25519 public key, a state generated by GenerateState
+// and the state's max age. Zero or negative maxAge disables expiration checks.
+// It returns a verification flag and the first encountered error.
+//
+// Malformed, tampered and expired states are reported as false with err == nil.
+func (sg ed25519StateGenerator) VerifyState(secret, state string, maxAge time.Duration) (bool, error) {
+	key, err := jwt.ParseEdPublicKeyFromPEM([]byte(secret))
+	if err != nil {
+		return false, ErrStateInvalidKey
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return false, ErrStateInvalidKey
+	}
+
+	unescaped, err := url.QueryUnescape(state)
+	if err != nil {
+		return false, nil
+	}
+
+	parts := strings.Split(unescaped, ".")
+	if len(parts) != 3 {
+		return false, nil
+	}
+
+	created, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return false, nil
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return false, nil
+	}
+
+	if !ed25519.Verify(publicKey, []byte(strings.Join(parts[1:], ".")), signature) {
+		return false, nil
+	}
+
+	if maxAge > 0 && time.Since(time.Unix(created, 0)) > maxAge {
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,103 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestEd25519Keys returns PEM encoded private and public keys.
+func newTestEd25519Keys(t *testing.T) (string, string) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate a key pair: %v", err)
+	}
+
+	private, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("could not marshal a private key: %v", err)
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("could not marshal a public key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+}
+
+func TestEd25519StateRoundTrip(t *testing.T) {
+	private, public := newTestEd25519Keys(t)
+	sg := newEd25519StateGenerator()
+	state, err := sg.GenerateState(private)
+	if err != nil {
+		t.Fatalf("could not generate a state: %v", err)
+	}
+
+	if ok, err := sg.VerifyState(public, state, time.Minute); !ok || err != nil {
+		t.Fatalf("expected a fresh state to be valid, got %v, %v", ok, err)
+	}
+
+	_, another := newTestEd25519Keys(t)
+	if ok, _ := sg.VerifyState(another, state, time.Minute); ok {
+		t.Fatal("expected a state to be invalid with another public key")
+	}
+}
+
+func TestEd25519StateTamperedPayload(t *testing.T) {
+	private, public := newTestEd25519Keys(t)
+	sg := newEd25519StateGenerator()
+	state, err := sg.GenerateState(private)
+	if err != nil {
+		t.Fatalf("could not generate a state: %v", err)
+	}
+
+	unescaped, err := url.QueryUnescape(state)
+	if err != nil {
+		t.Fatalf("could not unescape a state: %v", err)
+	}
+
+	parts := strings.Split(unescaped, ".")
+	parts[1] = strings.Repeat("0", len(parts[1]))
+	if ok, _ := sg.VerifyState(public, url.QueryEscape(strings.Join(parts, ".")), time.Minute); ok {
+		t.Fatal("expected a state with a tampered nonce to be invalid")
+	}
+}
+
+func TestEd25519StateInvalidKey(t *testing.T) {
+	sg := newEd25519StateGenerator()
+	if _, err := sg.GenerateState("secret"); !errors.Is(err, ErrStateInvalidKey) {
+		t.Fatalf("expected an invalid key error, got %v", err)
+	}
+
+	private, _ := newTestEd25519Keys(t)
+	if _, err := sg.VerifyState(private, "state", time.Minute); !errors.Is(err, ErrStateInvalidKey) {
+		t.Fatalf("expected a private key to be rejected for verification, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStateFresh(t *testing.T) {
+	sg := newStateGenerator()
+	state, err := sg.GenerateState("secret")
+	if err != nil {
+		t.Fatalf("could not generate a state: %v", err)
+	}
+
+	if ok, err := sg.VerifyState("secret", state, time.Minute); !ok || err != nil {
+		t.Fatalf("expected a fresh state to be valid, got %v, %v", ok, err)
+	}
+
+	if ok, _ := sg.VerifyState("another", state, time.Minute); ok {
+		t.Fatal("expected a state to be invalid with another secret")
+	}
+}
+
+func TestStateExpired(t *testing.T) {
+	payload := "nonce." + strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	signature, err := hmacBase64(payload, "secret")
+	if err != nil {
+		t.Fatalf("could not sign a state: %v", err)
+	}
+
+	state := url.QueryEscape(strings.ReplaceAll(signature+"."+payload, "+", ""))
+	sg := newStateGenerator()
+	if ok, _ := sg.VerifyState("secret", state, time.Minute); ok {
+		t.Fatal("expected an expired state to be invalid")
+	}
+
+	if ok, _ := sg.VerifyState("secret", state, 0); !ok {
+		t.Fatal("expected an expired state to be valid with expiration checks disabled")
+	}
+}
+
+func TestStateTamperedTimestamp(t *testing.T) {
+	sg := newStateGenerator()
+	state, err := sg.GenerateState("secret")
+	if err != nil {
+		t.Fatalf("could not generate a state: %v", err)
+	}
+
+	unescaped, err := url.QueryUnescape(state)
+	if err != nil {
+		t.Fatalf("could not unescape a state: %v", err)
+	}
+
+	parts := strings.Split(unescaped, ".")
+	parts[2] = strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	if ok, _ := sg.VerifyState("secret", url.QueryEscape(strings.Join(parts, ".")), time.Minute); ok {
+		t.Fatal("expected a state with a tampered timestamp to be invalid")
+	}
+}
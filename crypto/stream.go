@@ -0,0 +1,152 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package crypto provides basic cryptography wrappers and implementations for
+// encryption, token management and hashing.
+//
+// The crypto package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package crypto
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// _streamChunkSize is a plaintext chunk size used by stream encryption.
+const _streamChunkSize = 64 * 1024
+
+var ErrStreamTruncated = errors.New("encrypted stream is truncated")
+var ErrStreamMalformed = errors.New("encrypted stream is malformed")
+
+// encryptStream reads src in chunks and writes every chunk sealed with its own
+// random nonce into dst. Each chunk is prefixed with a 4 bytes sealed length and
+// a final chunk flag. Chunk index and the final flag are authenticated as additional data
+// so reordered, dropped or truncated chunks fail decryption.
+func encryptStream(aead cipher.AEAD, dst io.Writer, src io.Reader) error {
+	buf, next := make([]byte, _streamChunkSize), make([]byte, _streamChunkSize)
+	n, err := readChunk(src, buf)
+	if err != nil {
+		return err
+	}
+
+	for counter := uint64(0); ; counter++ {
+		m, err := readChunk(src, next)
+		if err != nil {
+			return err
+		}
+
+		final := m == 0
+		sealed, err := sealAEADWithData(aead, buf[:n], streamAdditionalData(counter, final))
+		if err != nil {
+			return err
+		}
+
+		header := make([]byte, 5)
+		binary.BigEndian.PutUint32(header, uint32(len(sealed)))
+		if final {
+			header[4] = 1
+		}
+
+		if _, err := dst.Write(header); err != nil {
+			return err
+		}
+
+		if _, err := dst.Write(sealed); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+
+		buf, next, n = next, buf, m
+	}
+}
+
+// decryptStream reads chunks produced by encryptStream from src and writes
+// decrypted chunks into dst.
+func decryptStream(aead cipher.AEAD, dst io.Writer, src io.Reader) error {
+	header := make([]byte, 5)
+	maxSize := aead.NonceSize() + _streamChunkSize + aead.Overhead()
+	buf := make([]byte, maxSize)
+	for counter := uint64(0); ; counter++ {
+		if _, err := io.ReadFull(src, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return ErrStreamTruncated
+			}
+
+			return err
+		}
+
+		size := int(binary.BigEndian.Uint32(header))
+		if size > maxSize || header[4] > 1 {
+			return ErrStreamMalformed
+		}
+
+		if _, err := io.ReadFull(src, buf[:size]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return ErrStreamTruncated
+			}
+
+			return err
+		}
+
+		final := header[4] == 1
+		plaintext, err := openAEADWithData(aead, buf[:size], streamAdditionalData(counter, final))
+		if err != nil {
+			return err
+		}
+
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if final {
+			if n, err := src.Read(header[:1]); n > 0 || (err != nil && err != io.EOF) {
+				return ErrStreamMalformed
+			}
+
+			return nil
+		}
+	}
+}
+
+// readChunk reads up to len(buf) bytes. EOF is not considered an error.
+func readChunk(src io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(src, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, nil
+	}
+
+	return n, err
+}
+
+// streamAdditionalData builds chunk's additional authenticated data.
+func streamAdditionalData(counter uint64, final bool) []byte {
+	data := make([]byte, 9)
+	binary.BigEndian.PutUint64(data, counter)
+	if final {
+		data[8] = 1
+	}
+
+	return data
+}
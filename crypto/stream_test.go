@@ -0,0 +1,163 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+var _errTestRead = errors.New("read failed")
+
+// failingReader returns data and fails afterwards.
+type failingReader struct {
+	data  *bytes.Reader
+	reads int
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	r.reads++
+	if r.data.Len() == 0 {
+		return 0, _errTestRead
+	}
+
+	return r.data.Read(p)
+}
+
+// splitTestStream splits an encrypted stream into header prefixed chunks.
+func splitTestStream(t *testing.T, stream []byte) [][]byte {
+	t.Helper()
+	var chunks [][]byte
+	for len(stream) > 0 {
+		if len(stream) < 5 {
+			t.Fatalf("expected a chunk header, got %d bytes", len(stream))
+		}
+
+		size := 5 + int(binary.BigEndian.Uint32(stream))
+		chunks = append(chunks, stream[:size])
+		stream = stream[size:]
+	}
+
+	return chunks
+}
+
+func newTestStream(t *testing.T, encryptor Encryptor, key, data []byte) []byte {
+	t.Helper()
+	var encrypted bytes.Buffer
+	if err := encryptor.EncryptStream(&encrypted, bytes.NewReader(data), key); err != nil {
+		t.Fatalf("could not encrypt a stream: %v", err)
+	}
+
+	return encrypted.Bytes()
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	data := make([]byte, 2*_streamChunkSize+_streamChunkSize/2)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("could not generate data: %v", err)
+	}
+
+	for name, encryptor := range map[string]Encryptor{"aes": newAesEncryptor(false), "chacha": newChachaEncryptor()} {
+		t.Run(name, func(t *testing.T) {
+			for _, size := range []int{0, _streamChunkSize, len(data)} {
+				stream := newTestStream(t, encryptor, key, data[:size])
+				if chunks := splitTestStream(t, stream); len(chunks) != max(1, (size+_streamChunkSize-1)/_streamChunkSize) {
+					t.Fatalf("expected %d bytes to be split into chunks, got %d", size, len(chunks))
+				}
+
+				var decrypted bytes.Buffer
+				if err := encryptor.DecryptStream(&decrypted, bytes.NewReader(stream), key); err != nil {
+					t.Fatalf("could not decrypt a stream: %v", err)
+				}
+
+				if !bytes.Equal(decrypted.Bytes(), data[:size]) {
+					t.Fatalf("expected a %d bytes round trip, got %d bytes", size, decrypted.Len())
+				}
+			}
+		})
+	}
+}
+
+func TestStreamDecryptCorrupted(t *testing.T) {
+	encryptor := newAesEncryptor(false)
+	key := []byte("0123456789abcdef0123456789abcdef")
+	chunks := splitTestStream(t, newTestStream(t, encryptor, key, make([]byte, 2*_streamChunkSize+1)))
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+
+	tampered := bytes.Clone(chunks[1])
+	tampered[len(tampered)-1] ^= 0x01
+	unflagged := bytes.Clone(chunks[2])
+	unflagged[4] = 0
+
+	tests := []struct {
+		name   string
+		chunks [][]byte
+		err    error
+	}{
+		{name: "missing final chunk", chunks: chunks[:2], err: ErrStreamTruncated},
+		{name: "truncated chunk", chunks: [][]byte{chunks[0], chunks[1][:10]}, err: ErrStreamTruncated},
+		{name: "reordered chunks", chunks: [][]byte{chunks[1], chunks[0], chunks[2]}},
+		{name: "dropped chunk", chunks: [][]byte{chunks[0], chunks[2]}},
+		{name: "tampered chunk", chunks: [][]byte{chunks[0], tampered, chunks[2]}},
+		{name: "final flag removed", chunks: [][]byte{chunks[0], chunks[1], unflagged}},
+		{name: "trailing data", chunks: [][]byte{chunks[0], chunks[1], chunks[2], chunks[0]}, err: ErrStreamMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := encryptor.DecryptStream(io.Discard, bytes.NewReader(bytes.Join(tt.chunks, nil)), key)
+			if err == nil {
+				t.Fatal("expected a corrupted stream to fail")
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestStreamReaderError(t *testing.T) {
+	encryptor := newAesEncryptor(false)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	src := &failingReader{data: bytes.NewReader(make([]byte, 10))}
+	var encrypted bytes.Buffer
+	if err := encryptor.EncryptStream(&encrypted, src, key); !errors.Is(err, _errTestRead) {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+
+	if src.reads > 2 || encrypted.Len() > 0 {
+		t.Fatalf("expected encryption to stop on the read error, got %d reads and %d bytes", src.reads, encrypted.Len())
+	}
+
+	stream := newTestStream(t, encryptor, key, make([]byte, 2*_streamChunkSize))
+	src = &failingReader{data: bytes.NewReader(splitTestStream(t, stream)[0])}
+	var decrypted bytes.Buffer
+	if err := encryptor.DecryptStream(&decrypted, src, key); !errors.Is(err, _errTestRead) {
+		t.Fatalf("expected the read error on decryption, got %v", err)
+	}
+}
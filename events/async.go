@@ -0,0 +1,160 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package events provides emitter adapters for services
+//
+// The events package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package events
+
+import "sync"
+
+type asyncEvent struct {
+	name    string
+	payload map[string]any
+}
+
+// asyncEmitter is an Emitter wrapper which runs listeners
+// on a bounded pool of background workers.
+type asyncEmitter struct {
+	emitter Emitter
+	queue   chan asyncEvent
+	// mu guards the closed flag. It is never held while queueing
+	// so that a blocked Fire does not stall Close.
+	mu     sync.RWMutex
+	closed bool
+	// done is closed by Close to release fires blocked on a full queue.
+	done chan struct{}
+	// pending tracks fires queueing payloads. The queue is closed
+	// once all of them return.
+	pending sync.WaitGroup
+	workers sync.WaitGroup
+}
+
+// An async Emitter constructor.
+//
+// Returns an Emitter compliant implementation which queues Fire payloads
+// and processes them with the number of workers provided. Fire returns
+// as soon as the payload is queued and blocks only when the queue is full.
+// Ordering across events is not guaranteed. Close drains the queue and
+// stops the workers.
+func NewAsyncEmitter(workers int) Emitter {
+	return newAsyncEmitter(NewGoKitEmitter(), workers, 1024)
+}
+
+func newAsyncEmitter(emitter Emitter, workers, size int) *asyncEmitter {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	a := &asyncEmitter{
+		emitter: emitter,
+		queue:   make(chan asyncEvent, size),
+		done:    make(chan struct{}),
+	}
+
+	a.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go a.work()
+	}
+
+	return a
+}
+
+func (a *asyncEmitter) work() {
+	defer a.workers.Done()
+	for e := range a.queue {
+		a.emitter.Fire(e.name, e.payload)
+	}
+}
+
+// Close stops accepting new payloads, waits for the queued ones to be
+// processed and stops the workers. Called automatically by fx and
+// bootstrapper on stop. Fire falls back to synchronous processing
+// once the emitter is closed, as do fires blocked on a full queue.
+func (a *asyncEmitter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		a.workers.Wait()
+		return nil
+	}
+
+	a.closed = true
+	a.mu.Unlock()
+
+	close(a.done)
+	a.pending.Wait()
+	close(a.queue)
+	a.workers.Wait()
+	return nil
+}
+
+// On is a subscription mechanism.
+// Takes an event name and a handler to process that event.
+func (a *asyncEmitter) On(name string, listener Listener) {
+	a.emitter.On(name, listener)
+}
+
+// OnWithPriority is a subscription mechanism.
+// Takes an event name, a handler and its priority.
+func (a *asyncEmitter) OnWithPriority(name string, listener Listener, priority int) {
+	a.emitter.OnWithPriority(name, listener, priority)
+}
+
+// Once is a single-shot subscription mechanism.
+// Takes an event name and a handler detached after the first handled event.
+func (a *asyncEmitter) Once(name string, listener Listener) {
+	a.emitter.Once(name, listener)
+}
+
+// Off is an unsubscription mechanism.
+// Takes an event name and a previously registered listener to remove.
+func (a *asyncEmitter) Off(name string, listener Listener) {
+	a.emitter.Off(name, listener)
+}
+
+// Fire is a publication mechanism.
+// Takes an event name and a payload to be processed in background.
+func (a *asyncEmitter) Fire(name string, payload map[string]any) {
+	a.mu.RLock()
+	if a.closed {
+		a.mu.RUnlock()
+		a.emitter.Fire(name, payload)
+		return
+	}
+
+	a.pending.Add(1)
+	a.mu.RUnlock()
+	defer a.pending.Done()
+
+	select {
+	case a.queue <- asyncEvent{name: name, payload: payload}:
+	case <-a.done:
+		a.emitter.Fire(name, payload)
+	}
+}
+
+// FireErr is a publication mechanism.
+// Takes an event name and a payload to be processed on the caller's
+// goroutine since the listener error is awaited.
+// Returns the first listener error.
+func (a *asyncEmitter) FireErr(name string, payload map[string]any) error {
+	return a.emitter.FireErr(name, payload)
+}
@@ -0,0 +1,137 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncEmitterFireReturnsImmediately(t *testing.T) {
+	emitter := newAsyncEmitter(NewGoKitEmitter(), 1, 8)
+	release := make(chan struct{})
+	handled := make(chan struct{})
+	emitter.On("async.fire", newTestListener(func(e Event) error {
+		<-release
+		close(handled)
+		return nil
+	}))
+
+	fired := make(chan struct{})
+	go func() {
+		emitter.Fire("async.fire", nil)
+		close(fired)
+	}()
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("expected Fire to return before the listener completes")
+	}
+
+	close(release)
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the listener to run in background")
+	}
+
+	if err := emitter.Close(); err != nil {
+		t.Fatalf("could not close the emitter: %v", err)
+	}
+}
+
+func TestAsyncEmitterCloseDrainsQueue(t *testing.T) {
+	emitter := newAsyncEmitter(NewGoKitEmitter(), 2, 64)
+	var handled atomic.Int32
+	emitter.On("async.drain", newTestListener(func(e Event) error {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+		return nil
+	}))
+
+	for i := 0; i < 32; i++ {
+		emitter.Fire("async.drain", nil)
+	}
+
+	if err := emitter.Close(); err != nil {
+		t.Fatalf("could not close the emitter: %v", err)
+	}
+
+	if n := handled.Load(); n != 32 {
+		t.Fatalf("expected every queued event to be handled on Close, got %d", n)
+	}
+
+	emitter.Fire("async.drain", nil)
+	if n := handled.Load(); n != 33 {
+		t.Fatalf("expected a closed emitter to fire synchronously, got %d", n)
+	}
+}
+
+func TestAsyncEmitterCloseWithBlockedFire(t *testing.T) {
+	emitter := newAsyncEmitter(NewGoKitEmitter(), 1, 1)
+	release := make(chan struct{})
+	var handled atomic.Int32
+	emitter.On("async.slow", newTestListener(func(e Event) error {
+		if e.Get("first") == true {
+			<-release
+			emitter.Fire("async.nested", nil)
+		}
+
+		handled.Add(1)
+		return nil
+	}))
+	emitter.On("async.nested", newTestListener(func(e Event) error {
+		handled.Add(1)
+		return nil
+	}))
+
+	emitter.Fire("async.slow", map[string]any{"first": true})
+	time.Sleep(50 * time.Millisecond)
+	emitter.Fire("async.slow", nil)
+
+	blocked := make(chan struct{})
+	go func() {
+		emitter.Fire("async.slow", nil)
+		close(blocked)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	closed := make(chan struct{})
+	go func() {
+		emitter.Close()
+		close(closed)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for _, done := range []chan struct{}{blocked, closed} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected Close and a blocked Fire not to deadlock")
+		}
+	}
+
+	if n := handled.Load(); n != 4 {
+		t.Fatalf("expected every event to be handled, got %d", n)
+	}
+}
@@ -0,0 +1,255 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package events provides emitter adapters for services
+//
+// The events package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"go-micro.dev/v4/broker"
+)
+
+// brokerMessage is a broker emitter wire format.
+type brokerMessage struct {
+	Name    string         `json:"name"`
+	Payload map[string]any `json:"payload"`
+}
+
+type brokerSubscription struct {
+	name     string
+	listener Listener
+	handler  func(ctx context.Context, body []byte) error
+	// subscriber is nil until the broker accepts the subscription.
+	subscriber broker.Subscriber
+	// fired identifies a once subscription.
+	fired *atomic.Bool
+}
+
+// brokerEmitter is a broker based distributed Emitter.
+type brokerEmitter struct {
+	broker        messaging.BrokerWithOptions
+	publisher     *messaging.Publisher
+	logger        log.Logger
+	mu            sync.Mutex
+	subscriptions []brokerSubscription
+}
+
+// A broker Emitter constructor.
+//
+// Returns an Emitter compliant implementation which publishes Fire payloads
+// as JSON to a broker topic named after the event and subscribes listeners
+// to that topic, so events fan out across processes. Wildcard patterns are
+// passed to the broker as is and follow its topic matching rules.
+// Trace context is propagated when tracing is enabled.
+//
+// Brokers refuse subscriptions until they connect, so listeners registered
+// earlier (e.g. from constructors) are kept and subscribed by Connect or the
+// next FireErr.
+func NewBrokerEmitter(
+	broker messaging.BrokerWithOptions, tracerConfig *config.TracerConfig, logger log.Logger,
+) Emitter {
+	if logger == nil {
+		logger = log.EmptyLogger{}
+	}
+
+	return &brokerEmitter{
+		broker:    broker,
+		publisher: messaging.NewPublisher(broker, tracerConfig),
+		logger:    logger,
+	}
+}
+
+// Connect connects the broker if it is not connected yet and subscribes
+// listeners registered before the broker connected.
+// It returns the first error encountered while connecting or subscribing.
+func (b *brokerEmitter) Connect() error {
+	if err := b.broker.Connect(); err != nil {
+		return err
+	}
+
+	return b.resubscribe()
+}
+
+// On is a subscription mechanism.
+// Takes an event name and a handler to process that event.
+// Subscriptions refused by the broker are logged and retried by
+// Connect and FireErr.
+func (b *brokerEmitter) On(name string, listener Listener) {
+	b.subscribe(name, listener, false)
+}
+
+// OnWithPriority is a subscription mechanism.
+// Broker subscriptions are independent so the priority is ignored
+// and listeners run in no particular order.
+func (b *brokerEmitter) OnWithPriority(name string, listener Listener, priority int) {
+	b.subscribe(name, listener, false)
+}
+
+// Once is a single-shot subscription mechanism.
+// Takes an event name and a handler unsubscribed after the first
+// handled event of this process.
+func (b *brokerEmitter) Once(name string, listener Listener) {
+	b.subscribe(name, listener, true)
+}
+
+// subscribe registers a listener. The lock is held while subscribing
+// so that a once subscription is recorded before it gets detached.
+func (b *brokerEmitter) subscribe(name string, listener Listener, once bool) {
+	var fired *atomic.Bool
+	if once {
+		fired = new(atomic.Bool)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	handler := func(ctx context.Context, body []byte) error {
+		if fired != nil {
+			if !fired.CompareAndSwap(false, true) {
+				return nil
+			}
+
+			go b.unsubscribe(fired)
+		}
+
+		var msg brokerMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return err
+		}
+
+		return listener.Handle(NewEvent(msg.Name, msg.Payload))
+	}
+
+	subscriber, err := b.publisher.Subscribe(name, handler)
+
+	if err != nil {
+		b.logger.Warnf("could not subscribe to %s, deferring the subscription until the broker connects: %s", name, err.Error())
+		subscriber = nil
+	}
+
+	b.subscriptions = append(b.subscriptions, brokerSubscription{
+		name:       name,
+		listener:   listener,
+		handler:    handler,
+		subscriber: subscriber,
+		fired:      fired,
+	})
+}
+
+// resubscribe subscribes deferred listeners.
+// It returns the first error encountered while subscribing.
+func (b *brokerEmitter) resubscribe() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var serr error
+	for i, s := range b.subscriptions {
+		if s.subscriber != nil {
+			continue
+		}
+
+		subscriber, err := b.publisher.Subscribe(s.name, s.handler)
+		if err != nil {
+			if serr == nil {
+				serr = err
+			}
+
+			continue
+		}
+
+		b.subscriptions[i].subscriber = subscriber
+	}
+
+	return serr
+}
+
+// unsubscribe drops a once subscription.
+func (b *brokerEmitter) unsubscribe(fired *atomic.Bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscriptions := b.subscriptions[:0]
+	for _, s := range b.subscriptions {
+		if s.fired == fired {
+			if s.subscriber != nil {
+				s.subscriber.Unsubscribe()
+			}
+
+			continue
+		}
+
+		subscriptions = append(subscriptions, s)
+	}
+
+	b.subscriptions = subscriptions
+}
+
+// Off is an unsubscription mechanism.
+// Takes an event name and a previously registered listener to remove.
+func (b *brokerEmitter) Off(name string, listener Listener) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscriptions := b.subscriptions[:0]
+	for _, s := range b.subscriptions {
+		if s.name == name && sameListener(s.listener, listener) {
+			if s.subscriber != nil {
+				s.subscriber.Unsubscribe()
+			}
+
+			continue
+		}
+
+		subscriptions = append(subscriptions, s)
+	}
+
+	b.subscriptions = subscriptions
+}
+
+// Fire is a publication mechanism.
+// Takes an event name and a payload to be published.
+func (b *brokerEmitter) Fire(name string, payload map[string]any) {
+	b.FireErr(name, payload)
+}
+
+// FireErr is a publication mechanism.
+// Takes an event name and a payload to be published.
+// Returns the first publishing error. Listener errors are
+// handled by remote subscribers and are not returned.
+// Deferred subscriptions are retried before publishing.
+func (b *brokerEmitter) FireErr(name string, payload map[string]any) error {
+	if err := b.resubscribe(); err != nil {
+		b.logger.Warnf("could not subscribe deferred listeners: %s", err.Error())
+	}
+
+	return b.publisher.Publish(context.Background(), name, brokerMessage{
+		Name:    name,
+		Payload: payload,
+	})
+}
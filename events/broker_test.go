@@ -0,0 +1,103 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/messaging"
+	"go-micro.dev/v4/registry"
+)
+
+func newTestBrokerEmitter(t *testing.T) Emitter {
+	t.Helper()
+	broker, err := messaging.NewBroker(registry.NewMemoryRegistry(), &config.BrokerConfig{})
+	if err != nil {
+		t.Fatalf("could not create a memory broker: %v", err)
+	}
+
+	if err := broker.Connect(); err != nil {
+		t.Fatalf("could not connect a memory broker: %v", err)
+	}
+
+	t.Cleanup(func() {
+		broker.Disconnect()
+	})
+
+	return NewBrokerEmitter(broker, nil, nil)
+}
+
+func TestBrokerEmitterFire(t *testing.T) {
+	emitter := newTestBrokerEmitter(t)
+	received := make(chan Event, 1)
+	emitter.On("broker.fire", newTestListener(func(e Event) error {
+		received <- e
+		return nil
+	}))
+
+	if err := emitter.FireErr("broker.fire", map[string]any{"key": "doc"}); err != nil {
+		t.Fatalf("could not fire an event: %v", err)
+	}
+
+	select {
+	case e := <-received:
+		if e.Name() != "broker.fire" || e.Get("key") != "doc" {
+			t.Fatalf("expected the event to be reconstructed, got %s %v", e.Name(), e.Data())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the listener to receive the event")
+	}
+}
+
+func TestBrokerEmitterOnBeforeConnect(t *testing.T) {
+	broker, err := messaging.NewBroker(registry.NewMemoryRegistry(), &config.BrokerConfig{})
+	if err != nil {
+		t.Fatalf("could not create a memory broker: %v", err)
+	}
+
+	t.Cleanup(func() {
+		broker.Disconnect()
+	})
+
+	emitter := NewBrokerEmitter(broker, nil, nil)
+	received := make(chan Event, 1)
+	emitter.On("broker.deferred", newTestListener(func(e Event) error {
+		received <- e
+		return nil
+	}))
+
+	if err := emitter.(*brokerEmitter).Connect(); err != nil {
+		t.Fatalf("could not connect a broker emitter: %v", err)
+	}
+
+	if err := emitter.FireErr("broker.deferred", map[string]any{"key": "doc"}); err != nil {
+		t.Fatalf("could not fire an event: %v", err)
+	}
+
+	select {
+	case e := <-received:
+		if e.Get("key") != "doc" {
+			t.Fatalf("expected the deferred listener to receive the payload, got %v", e.Data())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the deferred listener to receive the event")
+	}
+}
@@ -0,0 +1,105 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package events provides emitter adapters for services
+//
+// The events package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package events
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// basicEvent is a standalone Event implementation.
+type basicEvent struct {
+	name    string
+	payload map[string]any
+	aborted bool
+}
+
+// An Event constructor.
+//
+// Returns an Event compliant implementation with the name and
+// payload provided. Useful for testing listeners and for emitters
+// not backed by gookit.
+func NewEvent(name string, payload map[string]any) Event {
+	if payload == nil {
+		payload = make(map[string]any)
+	}
+
+	return &basicEvent{
+		name:    name,
+		payload: payload,
+	}
+}
+
+// Name returns event name.
+func (e *basicEvent) Name() string {
+	return e.name
+}
+
+// Get returns a payload by its key.
+func (e *basicEvent) Get(key string) any {
+	return e.payload[key]
+}
+
+// Data returns the whole payload.
+func (e *basicEvent) Data() map[string]any {
+	return e.payload
+}
+
+// Add adds a payload by its key.
+func (e *basicEvent) Add(key string, val any) {
+	e.payload[key] = val
+}
+
+// Abort interrupts event handling.
+func (e *basicEvent) Abort(abort bool) {
+	e.aborted = abort
+}
+
+// IsAborted returns aborted flag.
+func (e *basicEvent) IsAborted() bool {
+	return e.aborted
+}
+
+// DecodePayload maps an event payload into T. Fields are matched by
+// mapstructure tags or case-insensitive names.
+//
+// Returns an error naming the event when a payload value does not fit
+// its field or a field is missing in payload. Extra payload keys are ignored.
+func DecodePayload[T any](e Event) (T, error) {
+	var res T
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnset: true,
+		Result:     &res,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	if err := decoder.Decode(e.Data()); err != nil {
+		return res, fmt.Errorf("could not decode %s event payload: %w", e.Name(), err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,103 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventPayload(t *testing.T) {
+	e := NewEvent("document.created", nil)
+	if e.Name() != "document.created" {
+		t.Fatalf("unexpected event name %s", e.Name())
+	}
+
+	if e.Get("key") != nil {
+		t.Fatal("expected a missing payload key to be nil")
+	}
+
+	e.Add("key", "doc")
+	if e.Get("key") != "doc" || e.Data()["key"] != "doc" {
+		t.Fatalf("expected an added payload key, got %v", e.Data())
+	}
+}
+
+func TestEventAbort(t *testing.T) {
+	e := NewEvent("document.created", map[string]any{"key": "doc"})
+	if e.IsAborted() {
+		t.Fatal("expected a new event not to be aborted")
+	}
+
+	aborting := newTestListener(func(e Event) error {
+		e.Abort(true)
+		return nil
+	})
+
+	if err := aborting.Handle(e); err != nil || !e.IsAborted() {
+		t.Fatalf("expected an abort to propagate, got %v, %v", e.IsAborted(), err)
+	}
+
+	e.Abort(false)
+	if e.IsAborted() {
+		t.Fatal("expected an abort to be reverted")
+	}
+}
+
+type documentPayload struct {
+	Key  string `mapstructure:"key"`
+	Size int    `mapstructure:"size"`
+}
+
+func TestDecodePayload(t *testing.T) {
+	payload, err := DecodePayload[documentPayload](NewEvent("document.created", map[string]any{
+		"key": "doc", "size": 10, "extra": true,
+	}))
+	if err != nil {
+		t.Fatalf("could not decode a payload: %s", err.Error())
+	}
+
+	if payload.Key != "doc" || payload.Size != 10 {
+		t.Fatalf("expected a typed payload, got %+v", payload)
+	}
+}
+
+func TestDecodePayloadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload map[string]any
+		field   string
+	}{
+		{"mismatched type", map[string]any{"key": "doc", "size": "large"}, "size"},
+		{"missing field", map[string]any{"key": "doc"}, "size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodePayload[documentPayload](NewEvent("document.created", tt.payload))
+			if err == nil {
+				t.Fatal("expected a decoding error")
+			}
+
+			if !strings.Contains(err.Error(), "document.created") || !strings.Contains(err.Error(), tt.field) {
+				t.Fatalf("expected an error naming the event and the %s field, got %s", tt.field, err.Error())
+			}
+		})
+	}
+}
@@ -23,6 +23,16 @@
 // yaml configuration.
 package events
 
+import "github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+
+// Listener priorities. Listeners with a higher priority run first,
+// listeners with equal priorities run in the order of subscription.
+const (
+	PriorityLow    = -200
+	PriorityNormal = 0
+	PriorityHigh   = 200
+)
+
 // An Event provides basic contracts for event handling.
 // The implementation structure is expected to be initialized automatically by fx
 // and bootstrapper.
@@ -31,6 +41,8 @@ type Event interface {
 	Name() string
 	// Get returns a payload by its key.
 	Get(key string) any
+	// Data returns the whole payload.
+	Data() map[string]any
 	// Add adds a payload by its key.
 	Add(key string, val any)
 	// Abort interrupts event handling.
@@ -55,18 +67,50 @@ type Listener interface {
 // and bootstrapper
 type Emitter interface {
 	// On is a subscription mechanism.
-	// Takes an event name and a handler to process that event.
+	// Takes an event name or a pattern and a handler to process that event.
+	//
+	// Patterns are matched against fired event names:
+	//   - "*" matches every event;
+	//   - "document.*" matches events whose name is "document." followed by
+	//     a single segment, i.e. "document.created" but not
+	//     "document.page.created" or "document".
 	On(name string, listener Listener)
+	// OnWithPriority is a subscription mechanism.
+	// Same as On but listeners of an event with a higher priority
+	// run first. On subscribes with PriorityNormal.
+	OnWithPriority(name string, listener Listener, priority int)
+	// Once is a single-shot subscription mechanism.
+	// Takes an event name or a pattern and a handler which is detached
+	// after the first handled event. Only one of concurrent fires
+	// invokes the handler.
+	Once(name string, listener Listener)
+	// Off is an unsubscription mechanism.
+	// Takes an event name and a previously registered listener to remove.
+	Off(name string, listener Listener)
 	// Fire is a publication mechanism.
 	// Takes an event name and a payload to be processed.
 	Fire(name string, payload map[string]any)
+	// FireErr is a publication mechanism.
+	// Takes an event name and a payload to be processed.
+	// Returns the first listener error.
+	//
+	// A successful FireErr returns err == nil.
+	FireErr(name string, payload map[string]any) error
 }
 
 // An Emitter constructor. Called automatically by fx and
 // bootstrapper.
 //
 // Returns an emitter implementation based on configuration.
-// By default returns a gokit emitter.
-func NewEmitter() Emitter {
+// By default returns a gokit emitter. Returns an async
+// gokit emitter when async mode is enabled.
+func NewEmitter(config *config.EventsConfig) Emitter {
+	if config.Events.Async {
+		return newAsyncEmitter(
+			NewGoKitEmitter(), config.Events.Workers,
+			config.Events.QueueSize,
+		)
+	}
+
 	return NewGoKitEmitter()
 }
@@ -0,0 +1,32 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+// testListener is a comparable Listener backed by a function.
+type testListener struct {
+	handle func(e Event) error
+}
+
+func newTestListener(handle func(e Event) error) *testListener {
+	return &testListener{handle: handle}
+}
+
+func (l *testListener) Handle(e Event) error {
+	return l.handle(e)
+}
@@ -24,11 +24,43 @@
 package events
 
 import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
 	"github.com/gookit/event"
 )
 
+// gooKitListener adapts a Listener to gookit listener.
+type gooKitListener struct {
+	name     string
+	listener Listener
+	// once listeners are detached after the first handled event.
+	once   bool
+	fired  atomic.Bool
+	detach func(l *gooKitListener)
+}
+
+// Handle is an entry point for gookit event handling.
+func (l *gooKitListener) Handle(e event.Event) error {
+	if l.once {
+		if !l.fired.CompareAndSwap(false, true) {
+			return nil
+		}
+
+		// gookit may hold its lock while firing, so the listener
+		// is detached on a separate goroutine.
+		go l.detach(l)
+	}
+
+	return l.listener.Handle(e)
+}
+
 // gooKitEmitter is a gookit Emitter wrapper.
-type gooKitEmitter struct{}
+type gooKitEmitter struct {
+	mu        sync.Mutex
+	listeners []*gooKitListener
+}
 
 // A GoKit Emitter constructor. Called automatically by fx and
 // bootstrapper.
@@ -40,15 +72,92 @@ func NewGoKitEmitter() Emitter {
 }
 
 // On is a subscription mechanism.
-// Takes an event name and a handler to process that event.
-func (g gooKitEmitter) On(name string, listener Listener) {
-	event.On(name, event.ListenerFunc(func(e event.Event) error {
-		return listener.Handle(e)
-	}))
+// Takes an event name or a wildcard pattern ("*", "document.*") and a
+// handler to process that event. Patterns rely on gookit group listeners.
+func (g *gooKitEmitter) On(name string, listener Listener) {
+	g.OnWithPriority(name, listener, PriorityNormal)
+}
+
+// OnWithPriority is a subscription mechanism.
+// Takes an event name or a wildcard pattern, a handler and a gookit
+// priority. Listeners with a higher priority run first.
+func (g *gooKitEmitter) OnWithPriority(name string, listener Listener, priority int) {
+	l := &gooKitListener{name: name, listener: listener}
+	g.mu.Lock()
+	g.listeners = append(g.listeners, l)
+	g.mu.Unlock()
+	event.On(name, l, priority)
+}
+
+// Once is a single-shot subscription mechanism.
+// Takes an event name or a wildcard pattern and a handler which is
+// detached after the first handled event.
+func (g *gooKitEmitter) Once(name string, listener Listener) {
+	l := &gooKitListener{name: name, listener: listener, once: true, detach: g.remove}
+	g.mu.Lock()
+	g.listeners = append(g.listeners, l)
+	g.mu.Unlock()
+	event.On(name, l)
+}
+
+// remove detaches a single gookit listener.
+func (g *gooKitEmitter) remove(l *gooKitListener) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	listeners := g.listeners[:0]
+	for _, gl := range g.listeners {
+		if gl == l {
+			event.Std().RemoveListener(l.name, l)
+			continue
+		}
+
+		listeners = append(listeners, gl)
+	}
+
+	g.listeners = listeners
+}
+
+// Off is an unsubscription mechanism.
+// Takes an event name and a previously registered listener to remove.
+func (g *gooKitEmitter) Off(name string, listener Listener) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	listeners := g.listeners[:0]
+	for _, l := range g.listeners {
+		if l.name == name && sameListener(l.listener, listener) {
+			event.Std().RemoveListener(name, l)
+			continue
+		}
+
+		listeners = append(listeners, l)
+	}
+
+	g.listeners = listeners
 }
 
 // Fire is a publication mechanism.
 // Takes an event name and a payload to be processed.
-func (g gooKitEmitter) Fire(name string, payload map[string]any) {
+func (g *gooKitEmitter) Fire(name string, payload map[string]any) {
 	event.Fire(name, payload)
 }
+
+// FireErr is a publication mechanism.
+// Takes an event name and a payload to be processed.
+// Returns the first listener error.
+func (g *gooKitEmitter) FireErr(name string, payload map[string]any) error {
+	err, _ := event.Fire(name, payload)
+	return err
+}
+
+// sameListener compares listeners without panicking on
+// non-comparable implementations.
+func sameListener(a, b Listener) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb || ta == nil || !ta.Comparable() {
+		return false
+	}
+
+	return a == b
+}
@@ -0,0 +1,141 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGoKitEmitterOff(t *testing.T) {
+	emitter := NewGoKitEmitter()
+	var removed, kept int
+	removedListener := newTestListener(func(e Event) error {
+		removed++
+		return nil
+	})
+
+	emitter.On("gookit.off", removedListener)
+	emitter.On("gookit.off", newTestListener(func(e Event) error {
+		kept++
+		return nil
+	}))
+
+	emitter.Fire("gookit.off", nil)
+	emitter.Off("gookit.off", removedListener)
+	emitter.Fire("gookit.off", nil)
+
+	if removed != 1 {
+		t.Fatalf("expected a removed listener to run once, got %d", removed)
+	}
+
+	if kept != 2 {
+		t.Fatalf("expected a kept listener to run twice, got %d", kept)
+	}
+}
+
+func TestGoKitEmitterFireErr(t *testing.T) {
+	emitter := NewGoKitEmitter()
+	expected := errors.New("listener failure")
+	emitter.On("gookit.fire_err", newTestListener(func(e Event) error {
+		return expected
+	}))
+
+	if err := emitter.FireErr("gookit.fire_err", nil); !errors.Is(err, expected) {
+		t.Fatalf("expected a listener error, got %v", err)
+	}
+
+	if err := emitter.FireErr("gookit.fire_err.none", nil); err != nil {
+		t.Fatalf("expected no error without failing listeners, got %v", err)
+	}
+}
+
+func TestGoKitEmitterPattern(t *testing.T) {
+	emitter := NewGoKitEmitter()
+	var names []string
+	emitter.On("pattern.*", newTestListener(func(e Event) error {
+		names = append(names, e.Name())
+		return nil
+	}))
+
+	emitter.Fire("pattern.created", nil)
+	emitter.Fire("pattern.updated", nil)
+	emitter.Fire("pattern", nil)
+	emitter.Fire("other.created", nil)
+	emitter.Fire("pattern.page.created", nil)
+
+	if len(names) != 2 || names[0] != "pattern.created" || names[1] != "pattern.updated" {
+		t.Fatalf("expected only single segment pattern matches, got %v", names)
+	}
+}
+
+func TestGoKitEmitterOnce(t *testing.T) {
+	emitter := NewGoKitEmitter()
+	var once, regular atomic.Int32
+	emitter.Once("gookit.once", newTestListener(func(e Event) error {
+		once.Add(1)
+		return nil
+	}))
+	emitter.On("gookit.once", newTestListener(func(e Event) error {
+		regular.Add(1)
+		return nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emitter.Fire("gookit.once", nil)
+		}()
+	}
+
+	wg.Wait()
+	emitter.Fire("gookit.once", nil)
+
+	if n := once.Load(); n != 1 {
+		t.Fatalf("expected a once listener to run once, got %d", n)
+	}
+
+	if n := regular.Load(); n != 11 {
+		t.Fatalf("expected a regular listener to run on every fire, got %d", n)
+	}
+}
+
+func TestGoKitEmitterPriority(t *testing.T) {
+	emitter := NewGoKitEmitter()
+	var order []string
+	record := func(name string) Listener {
+		return newTestListener(func(e Event) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	emitter.On("gookit.priority", record("normal"))
+	emitter.OnWithPriority("gookit.priority", record("low"), PriorityLow)
+	emitter.OnWithPriority("gookit.priority", record("high"), PriorityHigh)
+	emitter.Fire("gookit.priority", nil)
+
+	if len(order) != 3 || order[0] != "high" || order[1] != "normal" || order[2] != "low" {
+		t.Fatalf("expected listeners to run by priority, got %v", order)
+	}
+}
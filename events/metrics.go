@@ -0,0 +1,172 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package events provides emitter adapters for services
+//
+// The events package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package events
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	eventsFired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "events_fired_total",
+		Help: "Total number of fired events.",
+	}, []string{"event"})
+	eventsHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "events_handled_total",
+		Help: "Total number of listener invocations.",
+	}, []string{"event"})
+	eventsErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "events_handler_errors_total",
+		Help: "Total number of listener errors.",
+	}, []string{"event"})
+	eventsDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "events_handler_duration_seconds",
+		Help:    "Listener latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"event"})
+	registerEventsMetrics sync.Once
+)
+
+// instrumentedListener records listener invocations, errors and latency.
+type instrumentedListener struct {
+	name     string
+	listener Listener
+	// release stops tracking once listeners after their first event.
+	release func()
+}
+
+func (l *instrumentedListener) Handle(e Event) error {
+	if l.release != nil {
+		l.release()
+	}
+
+	start := time.Now()
+	err := l.listener.Handle(e)
+	eventsHandled.WithLabelValues(e.Name()).Inc()
+	if err != nil {
+		eventsErrors.WithLabelValues(e.Name()).Inc()
+	}
+
+	eventsDuration.WithLabelValues(e.Name()).Observe(time.Since(start).Seconds())
+	return err
+}
+
+type instrumentedEmitter struct {
+	inner     Emitter
+	mu        sync.Mutex
+	listeners []*instrumentedListener
+}
+
+// NewInstrumentedEmitter wraps an Emitter to record fired events, listener
+// invocations, listener errors and listener latency labeled by event name.
+// Metrics are registered against the default prometheus registry.
+func NewInstrumentedEmitter(inner Emitter) Emitter {
+	registerEventsMetrics.Do(func() {
+		prometheus.MustRegister(eventsFired, eventsHandled, eventsErrors, eventsDuration)
+	})
+
+	return &instrumentedEmitter{inner: inner}
+}
+
+// wrap instruments and tracks a listener so that Off can find its wrapper.
+// Once wrappers are untracked after their first event.
+func (i *instrumentedEmitter) wrap(name string, listener Listener, once bool) *instrumentedListener {
+	l := &instrumentedListener{name: name, listener: listener}
+	if once {
+		var released sync.Once
+		l.release = func() {
+			released.Do(func() { i.untrack(l) })
+		}
+	}
+
+	i.mu.Lock()
+	i.listeners = append(i.listeners, l)
+	i.mu.Unlock()
+	return l
+}
+
+// untrack drops a listener wrapper.
+func (i *instrumentedEmitter) untrack(l *instrumentedListener) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for idx, tracked := range i.listeners {
+		if tracked == l {
+			i.listeners = append(i.listeners[:idx], i.listeners[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (i *instrumentedEmitter) On(name string, listener Listener) {
+	i.inner.On(name, i.wrap(name, listener, false))
+}
+
+func (i *instrumentedEmitter) OnWithPriority(name string, listener Listener, priority int) {
+	i.inner.OnWithPriority(name, i.wrap(name, listener, false), priority)
+}
+
+func (i *instrumentedEmitter) Once(name string, listener Listener) {
+	i.inner.Once(name, i.wrap(name, listener, true))
+}
+
+func (i *instrumentedEmitter) Off(name string, listener Listener) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	listeners := i.listeners[:0]
+	for _, l := range i.listeners {
+		if l.name == name && sameListener(l.listener, listener) {
+			i.inner.Off(name, l)
+			continue
+		}
+
+		listeners = append(listeners, l)
+	}
+
+	i.listeners = listeners
+}
+
+func (i *instrumentedEmitter) Fire(name string, payload map[string]any) {
+	eventsFired.WithLabelValues(name).Inc()
+	i.inner.Fire(name, payload)
+}
+
+func (i *instrumentedEmitter) FireErr(name string, payload map[string]any) error {
+	eventsFired.WithLabelValues(name).Inc()
+	return i.inner.FireErr(name, payload)
+}
+
+// Close closes the inner emitter if it supports closing.
+func (i *instrumentedEmitter) Close() error {
+	if closer, ok := i.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// metricValue returns a counter value or a histogram sample count.
+func metricValue(t *testing.T, metric prometheus.Metric) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := metric.Write(&m); err != nil {
+		t.Fatalf("could not read a metric: %s", err.Error())
+	}
+
+	if h := m.GetHistogram(); h != nil {
+		return float64(h.GetSampleCount())
+	}
+
+	return m.GetCounter().GetValue()
+}
+
+func TestInstrumentedEmitterMetrics(t *testing.T) {
+	emitter := NewInstrumentedEmitter(NewGoKitEmitter())
+	failure := errors.New("listener failure")
+	emitter.On("metrics.fired", newTestListener(func(e Event) error { return nil }))
+	emitter.On("metrics.fired", newTestListener(func(e Event) error { return failure }))
+
+	emitter.Fire("metrics.fired", nil)
+	if err := emitter.FireErr("metrics.fired", nil); !errors.Is(err, failure) {
+		t.Fatalf("expected a listener error, got %v", err)
+	}
+
+	if n := metricValue(t, eventsFired.WithLabelValues("metrics.fired")); n != 2 {
+		t.Fatalf("expected 2 fired events, got %v", n)
+	}
+
+	if n := metricValue(t, eventsHandled.WithLabelValues("metrics.fired")); n != 4 {
+		t.Fatalf("expected 4 listener invocations, got %v", n)
+	}
+
+	if n := metricValue(t, eventsErrors.WithLabelValues("metrics.fired")); n != 2 {
+		t.Fatalf("expected 2 listener errors, got %v", n)
+	}
+
+	if n := metricValue(t, eventsDuration.WithLabelValues("metrics.fired").(prometheus.Metric)); n != 4 {
+		t.Fatalf("expected 4 listener latency observations, got %v", n)
+	}
+}
+
+func TestInstrumentedEmitterOnceUntracked(t *testing.T) {
+	emitter := NewInstrumentedEmitter(NewGoKitEmitter()).(*instrumentedEmitter)
+	kept := newTestListener(func(e Event) error { return nil })
+	emitter.On("metrics.once", kept)
+	emitter.Once("metrics.once", newTestListener(func(e Event) error { return nil }))
+	if len(emitter.listeners) != 2 {
+		t.Fatalf("expected 2 tracked listeners, got %d", len(emitter.listeners))
+	}
+
+	emitter.Fire("metrics.once", nil)
+	emitter.Fire("metrics.once", nil)
+	if len(emitter.listeners) != 1 || emitter.listeners[0].listener != kept {
+		t.Fatalf("expected the once listener to be untracked after its first event, got %d", len(emitter.listeners))
+	}
+}
@@ -0,0 +1,60 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package functions provides functional convenience structures
+//
+// The functional package should  be configured manually unlike the other packages from the module.
+package functional
+
+// Parallel composes independent actions into a single action. Every action
+// gets its own copy of the input and runs concurrently. Results are passed
+// to merge in the actions' order.
+//
+// The first action error is returned without waiting for the rest, wrapped
+// in a *PipeError with the failing action's index.
+//
+// The returned action can be used as a regular pipe step:
+//
+//	functional.NewPipe[Doc]().Next(parse).Next(functional.Parallel(merge, thumbnail, index))
+func Parallel[T any](merge func(results []T) (T, error), actions ...func(T) (T, error)) func(T) (T, error) {
+	return func(input T) (T, error) {
+		results := make([]T, len(actions))
+		errs := make(chan error, len(actions))
+		for i, fn := range actions {
+			go func(i int, fn func(T) (T, error)) {
+				res, err := fn(input)
+				if err != nil {
+					errs <- &PipeError{Index: i, Err: err}
+					return
+				}
+
+				results[i] = res
+				errs <- nil
+			}(i, fn)
+		}
+
+		for range actions {
+			if err := <-errs; err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+
+		return merge(results)
+	}
+}
@@ -0,0 +1,86 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package functional
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParallelConcurrent(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(3)
+	action := func(delta int) func(int) (int, error) {
+		return func(input int) (int, error) {
+			started.Done()
+			// Blocks until every action has started, so a sequential run deadlocks.
+			started.Wait()
+			return input + delta, nil
+		}
+	}
+
+	sum := func(results []int) (int, error) {
+		var total int
+		for _, res := range results {
+			total += res
+		}
+
+		return total, nil
+	}
+
+	done := make(chan struct{})
+	var res int
+	var err error
+	go func() {
+		res, err = Parallel(sum, action(1), action(2), action(3))(10)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected actions to run concurrently")
+	}
+
+	if err != nil || res != 36 {
+		t.Fatalf("expected merged results, got %d, %v", res, err)
+	}
+}
+
+func TestParallelError(t *testing.T) {
+	errFailed := errors.New("failed")
+	release := make(chan struct{})
+	defer close(release)
+
+	_, err := Parallel(
+		func(results []int) (int, error) {
+			t.Fatal("expected merge to be skipped on error")
+			return 0, nil
+		},
+		func(input int) (int, error) { <-release; return input, nil },
+		func(input int) (int, error) { return 0, errFailed },
+	)(1)
+
+	var perr *PipeError
+	if !errors.As(err, &perr) || perr.Index != 1 || !errors.Is(err, errFailed) {
+		t.Fatalf("expected the failing action error, got %v", err)
+	}
+}
@@ -21,11 +21,38 @@
 // The functional package should  be configured manually unlike the other packages from the module.
 package functional
 
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
 type action[T any] func(input T) (T, error)
 
+// step is a pipe action with an optional compensation.
+type step[T any] struct {
+	do   action[T]
+	undo func(T) error
+}
+
+// PipeError is returned when a pipe action fails. It keeps the failing
+// action's index and the original error.
+type PipeError struct {
+	Index int
+	Err   error
+}
+
+func (e *PipeError) Error() string {
+	return fmt.Sprintf("pipe step %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *PipeError) Unwrap() error {
+	return e.Err
+}
+
 // Pipe is a utility structure for functions composition.
 type Pipe[T any] struct {
-	chain []action[T]
+	chain []step[T]
 }
 
 // NewPipe initializes a new pipe for functions composition.
@@ -35,20 +62,74 @@ func NewPipe[T any]() *Pipe[T] {
 
 // Next appends a new handler function to the pipe.
 func (p *Pipe[T]) Next(f action[T]) *Pipe[T] {
-	p.chain = append(p.chain, f)
+	p.chain = append(p.chain, step[T]{do: f})
 	return p
 }
 
-// Do starts chain execution.
+// NextWithCompensation appends a new handler function along with its
+// compensation. When a later action fails, compensations of all the
+// successfully executed actions are called in reverse order with
+// the respective action's result.
+func (p *Pipe[T]) NextWithCompensation(do action[T], undo func(T) error) *Pipe[T] {
+	p.chain = append(p.chain, step[T]{do: do, undo: undo})
+	return p
+}
+
+// Do starts chain execution with a zero value input.
 func (p *Pipe[T]) Do() (T, error) {
-	var res T
+	var input T
+	return p.DoWith(input)
+}
+
+// DoWith starts chain execution feeding input into the first action.
+func (p *Pipe[T]) DoWith(input T) (T, error) {
+	return p.DoCtx(context.Background(), input)
+}
+
+// DoCtx starts chain execution feeding input into the first action.
+// The context is checked before each action and the chain is aborted
+// with the context error once it is done. Long-running actions are
+// expected to honor cancellation themselves. Action errors are
+// wrapped in a *PipeError.
+//
+// On failure registered compensations are run in reverse order and their
+// errors are joined with the original one.
+func (p *Pipe[T]) DoCtx(ctx context.Context, input T) (T, error) {
+	res := input
 	var err error
-	for _, fn := range p.chain {
-		res, err = fn(res)
+	executed := make([]int, 0, len(p.chain))
+	results := make([]T, 0, len(p.chain))
+	for i, s := range p.chain {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		res, err = s.do(res)
 		if err != nil {
+			err = &PipeError{Index: i, Err: err}
 			break
 		}
+
+		executed = append(executed, i)
+		results = append(results, res)
+	}
+
+	if err == nil {
+		return res, nil
+	}
+
+	errs := []error{err}
+	for j := len(executed) - 1; j >= 0; j-- {
+		if undo := p.chain[executed[j]].undo; undo != nil {
+			if uerr := undo(results[j]); uerr != nil {
+				errs = append(errs, &PipeError{Index: executed[j], Err: uerr})
+			}
+		}
+	}
+
+	if len(errs) == 1 {
+		return res, err
 	}
 
-	return res, err
+	return res, errors.Join(errs...)
 }
@@ -0,0 +1,108 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package functional
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPipeDoWith(t *testing.T) {
+	res, err := NewPipe[int]().
+		Next(func(input int) (int, error) { return input + 1, nil }).
+		Next(func(input int) (int, error) { return input * 10, nil }).
+		DoWith(4)
+	if err != nil {
+		t.Fatalf("unexpected pipe error: %s", err.Error())
+	}
+
+	if res != 50 {
+		t.Fatalf("expected the seed to be threaded through the pipe, got %d", res)
+	}
+}
+
+func TestPipeDoCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	_, err := NewPipe[int]().
+		Next(func(input int) (int, error) { calls++; return input, nil }).
+		Next(func(input int) (int, error) { calls++; cancel(); return input, nil }).
+		Next(func(input int) (int, error) { calls++; return input, nil }).
+		Next(func(input int) (int, error) { calls++; return input, nil }).
+		DoCtx(ctx, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected the remaining steps to be skipped, got %d calls", calls)
+	}
+}
+
+func TestPipeErrorIndex(t *testing.T) {
+	errStep := errors.New("step failed")
+	_, err := NewPipe[int]().
+		Next(func(input int) (int, error) { return input, nil }).
+		Next(func(input int) (int, error) { return input, nil }).
+		Next(func(input int) (int, error) { return input, errStep }).
+		Next(func(input int) (int, error) { return input, nil }).
+		Do()
+
+	var perr *PipeError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a pipe error, got %v", err)
+	}
+
+	if perr.Index != 2 {
+		t.Fatalf("expected the failing step index 2, got %d", perr.Index)
+	}
+
+	if !errors.Is(err, errStep) {
+		t.Fatalf("expected the original error to be accessible, got %v", err)
+	}
+}
+
+func TestPipeCompensation(t *testing.T) {
+	errFailed := errors.New("failed")
+	errUndo := errors.New("could not undo")
+
+	var undone []int
+	_, err := NewPipe[int]().
+		NextWithCompensation(
+			func(input int) (int, error) { return input + 1, nil },
+			func(res int) error { undone = append(undone, res); return nil },
+		).
+		NextWithCompensation(
+			func(input int) (int, error) { return input + 1, nil },
+			func(res int) error { undone = append(undone, res); return errUndo },
+		).
+		Next(func(input int) (int, error) { return input, errFailed }).
+		DoWith(0)
+
+	if !errors.Is(err, errFailed) || !errors.Is(err, errUndo) {
+		t.Fatalf("expected the step and compensation errors to be joined, got %v", err)
+	}
+
+	if len(undone) != 2 || undone[0] != 2 || undone[1] != 1 {
+		t.Fatalf("expected compensations to run in reverse order, got %v", undone)
+	}
+}
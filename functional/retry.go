@@ -0,0 +1,65 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package functions provides functional convenience structures
+//
+// The functional package should  be configured manually unlike the other packages from the module.
+package functional
+
+import (
+	"context"
+	"time"
+)
+
+// Retry wraps an action to be retried on error with a fixed backoff.
+// It returns the first successful value or the last error after
+// exhausting attempts. Attempts below one are treated as a single attempt.
+func Retry[T any](action func(T) (T, error), attempts int, backoff time.Duration) func(T) (T, error) {
+	return RetryCtx(context.Background(), action, attempts, backoff)
+}
+
+// RetryCtx wraps an action to be retried on error with a fixed backoff
+// while the context is not done. It returns the first successful value,
+// the context error once the context is done or the last error after
+// exhausting attempts.
+func RetryCtx[T any](
+	ctx context.Context, action func(T) (T, error),
+	attempts int, backoff time.Duration,
+) func(T) (T, error) {
+	return func(input T) (T, error) {
+		var res T
+		var err error
+		for i := 0; i < max(attempts, 1); i++ {
+			if i > 0 {
+				timer := time.NewTimer(backoff)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return res, ctx.Err()
+				case <-timer.C:
+				}
+			}
+
+			if res, err = action(input); err == nil {
+				return res, nil
+			}
+		}
+
+		return res, err
+	}
+}
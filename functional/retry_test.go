@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package functional
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetrySucceedsOnSecondTry(t *testing.T) {
+	var calls int
+	res, err := Retry(func(input int) (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, errors.New("temporary failure")
+		}
+
+		return input * 2, nil
+	}, 3, time.Millisecond)(21)
+	if err != nil {
+		t.Fatalf("unexpected retry error: %s", err.Error())
+	}
+
+	if res != 42 || calls != 2 {
+		t.Fatalf("expected success on the second try, got %d after %d calls", res, calls)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	_, err := Retry(func(input int) (int, error) {
+		calls++
+		return 0, fmt.Errorf("attempt %d", calls)
+	}, 3, time.Millisecond)(1)
+	if err == nil || err.Error() != "attempt 3" {
+		t.Fatalf("expected the last attempt error, got %v", err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	_, err := RetryCtx(ctx, func(input int) (int, error) {
+		calls++
+		cancel()
+		return 0, errors.New("temporary failure")
+	}, 5, time.Second)(1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a context cancellation error, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected retries to stop on cancellation, got %d calls", calls)
+	}
+}
@@ -0,0 +1,129 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package health provides a health checks aggregator for adapters' subsystems
+// (storage, cache, broker, worker etc).
+//
+// Aggregated checks are registered with the repl service via fx's
+// "health_checks" group. The bootstrapper registers cache and broker
+// checks out of the box. Other dependencies are added the same way:
+//
+//	fx.Provide(fx.Annotate(
+//		func() []healthgo.Config {
+//			return health.NewAggregator(health.Component{
+//				Name: "docserver",
+//				Pinger: health.PingerFunc(func(ctx context.Context) error {
+//					req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docserver/healthcheck", nil)
+//					if err != nil {
+//						return err
+//					}
+//
+//					resp, err := http.DefaultClient.Do(req)
+//					if err != nil {
+//						return err
+//					}
+//
+//					return resp.Body.Close()
+//				}),
+//			}).Checks()
+//		},
+//		fx.ResultTags(`group:"health_checks,flatten"`),
+//	))
+package health
+
+import (
+	"context"
+	"time"
+
+	healthgo "github.com/hellofresh/health-go/v5"
+)
+
+// A Pinger checks a subsystem's connectivity.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// A PingerFunc is a function adapter for Pinger.
+type PingerFunc func(ctx context.Context) error
+
+// Ping calls f(ctx).
+func (f PingerFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+// A Component describes a single subsystem health check.
+type Component struct {
+	// Name is a check's name.
+	Name string
+	// Pinger is a subsystem connectivity check. Components without
+	// a pinger are skipped.
+	Pinger Pinger
+	// Critical components report the service as DOWN (Unavailable) on
+	// failure. Failures of non-critical ones report it as DEGRADED
+	// (Partially Available).
+	Critical bool
+	// Timeout is a check timeout.
+	//
+	// By default - 3s.
+	Timeout time.Duration
+}
+
+// An Aggregator collects subsystems' health components.
+type Aggregator struct {
+	components []Component
+}
+
+// NewAggregator creates an aggregator with optional components.
+func NewAggregator(components ...Component) *Aggregator {
+	a := &Aggregator{}
+	for _, c := range components {
+		a.Add(c)
+	}
+
+	return a
+}
+
+// Add adds a component. Components without a pinger are ignored, so that
+// optional subsystems may be passed as is.
+func (a *Aggregator) Add(c Component) *Aggregator {
+	if c.Pinger == nil {
+		return a
+	}
+
+	if c.Timeout <= 0 {
+		c.Timeout = 3 * time.Second
+	}
+
+	a.components = append(a.components, c)
+	return a
+}
+
+// Checks converts components into health checks.
+func (a *Aggregator) Checks() []healthgo.Config {
+	checks := make([]healthgo.Config, 0, len(a.components))
+	for _, c := range a.components {
+		checks = append(checks, healthgo.Config{
+			Name:      c.Name,
+			Timeout:   c.Timeout,
+			SkipOnErr: !c.Critical,
+			Check:     c.Pinger.Ping,
+		})
+	}
+
+	return checks
+}
@@ -0,0 +1,80 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	healthgo "github.com/hellofresh/health-go/v5"
+)
+
+var (
+	healthy = PingerFunc(func(ctx context.Context) error { return nil })
+	failing = PingerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+)
+
+func TestAggregatorChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical bool
+		status   healthgo.Status
+	}{
+		{"non-critical failure", false, healthgo.StatusPartiallyAvailable},
+		{"critical failure", true, healthgo.StatusUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := healthgo.New(healthgo.WithChecks(NewAggregator(
+				Component{Name: "broker", Pinger: healthy, Critical: true},
+				Component{Name: "cache", Pinger: failing, Critical: tt.critical},
+			).Checks()...))
+			if err != nil {
+				t.Fatalf("could not create health checks: %s", err.Error())
+			}
+
+			check := h.Measure(context.Background())
+			if check.Status != tt.status {
+				t.Fatalf("expected %s status, got %s", tt.status, check.Status)
+			}
+
+			if _, ok := check.Failures["cache"]; !ok || len(check.Failures) != 1 {
+				t.Fatalf("expected only the cache check to fail, got %v", check.Failures)
+			}
+		})
+	}
+}
+
+func TestAggregatorAdd(t *testing.T) {
+	checks := NewAggregator(
+		Component{Name: "cache"},
+		Component{Name: "broker", Pinger: healthy},
+	).Checks()
+
+	if len(checks) != 1 || checks[0].Name != "broker" {
+		t.Fatalf("expected components without a pinger to be skipped, got %v", checks)
+	}
+
+	if checks[0].Timeout != 3*time.Second {
+		t.Fatalf("expected a default timeout, got %v", checks[0].Timeout)
+	}
+}
@@ -39,3 +39,13 @@ type LogElasticInitializationError struct {
 func (e *LogElasticInitializationError) Error() string {
 	return fmt.Sprintf("could not initialize an elastic client/hook with address: %s. Cause: %s", e.Address, e.Cause.Error())
 }
+
+// LogSyslogInitializationError fires when a syslog hook throws an error.
+type LogSyslogInitializationError struct {
+	Address string
+	Cause   error
+}
+
+func (e *LogSyslogInitializationError) Error() string {
+	return fmt.Sprintf("could not initialize a syslog hook with address: %s. Cause: %s", e.Address, e.Cause.Error())
+}
@@ -129,6 +129,15 @@ func NewLogrusLogger(config *config.LoggerConfig) (Logger, error) {
 		log.AddHook(hook)
 	}
 
+	if config.Logger.Syslog.Address != "" {
+		hook, err := createSyslogHook(config.Logger.Syslog, config.Logger.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		log.AddHook(hook)
+	}
+
 	return LogrusLogger{
 		logger: log,
 		config: *config,
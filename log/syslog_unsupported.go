@@ -0,0 +1,42 @@
+//go:build windows || plan9
+
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package log provides generic interface and implementations for
+// logging.
+//
+// The log package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package log
+
+import (
+	"errors"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/sirupsen/logrus"
+)
+
+// createSyslogHook is not supported on this platform.
+func createSyslogHook(config config.SyslogLogConfig, name string) (logrus.Hook, error) {
+	return nil, &LogSyslogInitializationError{
+		Address: config.Address,
+		Cause:   errors.New("syslog is not supported on this platform"),
+	}
+}
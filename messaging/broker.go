@@ -24,10 +24,19 @@
 package messaging
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"os"
+	"strings"
+
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/go-micro/plugins/v4/broker/kafka"
 	"github.com/go-micro/plugins/v4/broker/memory"
 	"github.com/go-micro/plugins/v4/broker/nats"
 	"github.com/go-micro/plugins/v4/broker/rabbitmq"
+	natsp "github.com/nats-io/nats.go"
 	"go-micro.dev/v4/broker"
 	"go-micro.dev/v4/registry"
 )
@@ -40,11 +49,48 @@ type BrokerWithOptions struct {
 	SubOptions broker.SubscribeOptions
 }
 
+// Connect establishes a broker connection. Called automatically by
+// fx and bootstrapper on start.
+//
+// A successful Connect returns err == nil. Failures are wrapped
+// with ErrBrokerUnavailable.
+func (b BrokerWithOptions) Connect() error {
+	return wrapError(ErrBrokerUnavailable, b.Broker.Connect())
+}
+
+// Ping checks broker connectivity. Connect is a no-op for connected
+// brokers, so Ping only reconnects brokers which lost their connection.
+//
+// A successful Ping returns err == nil. Failures are wrapped
+// with ErrBrokerUnavailable.
+func (b BrokerWithOptions) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Connect()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return wrapError(ErrBrokerUnavailable, ctx.Err())
+	}
+}
+
+// Disconnect drains and closes a broker connection. Called automatically by
+// fx and bootstrapper on stop.
+//
+// A successful Disconnect returns err == nil.
+func (b BrokerWithOptions) Disconnect() error {
+	return b.Broker.Disconnect()
+}
+
 // A BrokerWrapper constructor. Called automatically by fx and
 // bootstrapper with config path provided via cli.
 //
-// Returns a wrapper broker instance used to initialize a go-micro broker.
-func NewBroker(registry registry.Registry, config *config.BrokerConfig) BrokerWithOptions {
+// Returns a wrapper broker instance used to initialize a go-micro broker
+// and the first error encountered while configuring TLS.
+func NewBroker(registry registry.Registry, config *config.BrokerConfig) (BrokerWithOptions, error) {
 	bo := []broker.Option{
 		broker.Addrs(config.Messaging.Addrs...),
 		broker.Registry(registry),
@@ -58,12 +104,41 @@ func NewBroker(registry registry.Registry, config *config.BrokerConfig) BrokerWi
 		return BrokerWithOptions{
 			Broker:     b,
 			SubOptions: subOpts,
+		}, nil
+	}
+
+	if config.Messaging.TLS {
+		tlsConfig, err := newTLSConfig(config.Messaging.TLSCAFile)
+		if err != nil {
+			return BrokerWithOptions{}, err
 		}
+
+		bo = append(bo, broker.Secure(true), broker.TLSConfig(tlsConfig))
 	}
 
 	switch config.Messaging.Type {
 	case 1:
-		bo = append(bo, rabbitmq.WithoutExchange())
+		if config.Messaging.Username != "" {
+			addrs, err := withCredentials(
+				config.Messaging.Addrs, config.Messaging.Username,
+				config.Messaging.Password, config.Messaging.TLS,
+			)
+			if err != nil {
+				return BrokerWithOptions{}, err
+			}
+
+			bo = append(bo, broker.Addrs(addrs...))
+		}
+
+		if config.Messaging.ExchangeName != "" {
+			bo = append(bo, rabbitmq.ExchangeName(config.Messaging.ExchangeName))
+			if config.Messaging.ExchangeDurable {
+				bo = append(bo, rabbitmq.DurableExchange())
+			}
+		} else {
+			bo = append(bo, rabbitmq.WithoutExchange())
+		}
+
 		b = rabbitmq.NewBroker(bo...)
 
 		opts := []broker.SubscribeOption{}
@@ -85,7 +160,38 @@ func NewBroker(registry registry.Registry, config *config.BrokerConfig) BrokerWi
 
 		subOpts = broker.NewSubscribeOptions(opts...)
 	case 2:
+		if config.Messaging.Username != "" {
+			nopts := natsp.GetDefaultOptions()
+			nopts.User = config.Messaging.Username
+			nopts.Password = config.Messaging.Password
+			bo = append(bo, nats.Options(nopts))
+		}
+
 		b = nats.NewBroker(bo...)
+
+		opts := []broker.SubscribeOption{}
+		if config.Messaging.DisableAutoAck {
+			opts = append(opts, broker.DisableAutoAck())
+		}
+
+		if config.Messaging.Durable {
+			opts = append(opts, broker.Queue(config.Messaging.ConsumerGroup))
+		}
+
+		subOpts = broker.NewSubscribeOptions(opts...)
+	case 3:
+		b = kafka.NewBroker(bo...)
+
+		opts := []broker.SubscribeOption{}
+		if config.Messaging.ConsumerGroup != "" {
+			opts = append(opts, broker.Queue(config.Messaging.ConsumerGroup))
+		}
+
+		if config.Messaging.DisableAutoAck {
+			opts = append(opts, broker.DisableAutoAck())
+		}
+
+		subOpts = broker.NewSubscribeOptions(opts...)
 	default:
 		b = memory.NewBroker(bo...)
 	}
@@ -93,5 +199,52 @@ func NewBroker(registry registry.Registry, config *config.BrokerConfig) BrokerWi
 	return BrokerWithOptions{
 		Broker:     b,
 		SubOptions: subOpts,
+	}, nil
+}
+
+// newTLSConfig builds a client TLS configuration. Uses the CA
+// certificate provided or system CAs when path is empty.
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
 	}
+
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, ErrInvalidCACertificate
+	}
+
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// withCredentials injects username and password into amqp addresses.
+func withCredentials(addrs []string, username, password string, secure bool) ([]string, error) {
+	scheme := "amqp://"
+	if secure {
+		scheme = "amqps://"
+	}
+
+	res := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if !strings.Contains(addr, "://") {
+			addr = scheme + addr
+		}
+
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		u.User = url.UserPassword(username, password)
+		res = append(res, u.String())
+	}
+
+	return res, nil
 }
@@ -0,0 +1,47 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/registry"
+)
+
+func newTestBroker(t *testing.T) BrokerWithOptions {
+	t.Helper()
+	b, err := NewBroker(registry.NewMemoryRegistry(), &config.BrokerConfig{})
+	if err != nil {
+		t.Fatalf("could not create a memory broker: %v", err)
+	}
+
+	return b
+}
+
+func TestBrokerConnectDisconnect(t *testing.T) {
+	b := newTestBroker(t)
+	if err := b.Connect(); err != nil {
+		t.Fatalf("could not connect a memory broker: %v", err)
+	}
+
+	if err := b.Disconnect(); err != nil {
+		t.Fatalf("could not disconnect a memory broker: %v", err)
+	}
+}
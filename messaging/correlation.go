@@ -0,0 +1,62 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package messaging provides a broker wrapper for go-micro broker.
+//
+// The messaging package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package messaging
+
+import (
+	"context"
+	"strings"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go-micro.dev/v4/broker"
+)
+
+// CorrelationIDHeader is a message header carrying the request ID
+// set by chi's RequestID middleware at the HTTP edge.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// CorrelationIDFromContext returns a correlation ID of the current
+// request or message. Returns an empty string when there is none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	return chimiddleware.GetReqID(ctx)
+}
+
+// ContextWithCorrelationID returns a copy of ctx carrying id.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, chimiddleware.RequestIDKey, id)
+}
+
+// CorrelationMiddleware extracts a correlation ID from message headers
+// into the handler's context.
+func CorrelationMiddleware(next Handler) Handler {
+	return func(ctx context.Context, msg *broker.Message) error {
+		for k, v := range msg.Header {
+			if v != "" && strings.EqualFold(k, CorrelationIDHeader) {
+				ctx = ContextWithCorrelationID(ctx, v)
+				break
+			}
+		}
+
+		return next(ctx, msg)
+	}
+}
@@ -0,0 +1,55 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package messaging provides a broker wrapper for go-micro broker.
+//
+// The messaging package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package messaging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCACertificate is returned when a broker CA certificate
+// (messaging.tls_ca_file yaml or BROKER_TLS_CA_FILE env parameter) could not be parsed.
+var ErrInvalidCACertificate = errors.New("could not parse broker CA certificate")
+
+// ErrBrokerUnavailable is returned when a broker connection could not
+// be established. The driver error is wrapped.
+var ErrBrokerUnavailable = errors.New("broker is unavailable")
+
+// ErrPublishFailed is returned when a broker fails to publish a message.
+// The driver error is wrapped.
+var ErrPublishFailed = errors.New("could not publish a message")
+
+// ErrSubscribeFailed is returned when a broker fails to register a subscriber.
+// The driver error is wrapped.
+var ErrSubscribeFailed = errors.New("could not subscribe to a topic")
+
+// wrapError wraps a driver error with a messaging error. Returns nil
+// when err is nil.
+func wrapError(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", kind, err)
+}
@@ -0,0 +1,67 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package messaging provides a broker wrapper for go-micro broker.
+//
+// The messaging package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package messaging
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/middleware"
+	"go-micro.dev/v4/broker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// A Handler processes a single broker message.
+type Handler func(ctx context.Context, msg *broker.Message) error
+
+// A Middleware wraps a Handler with cross-cutting logic.
+type Middleware func(next Handler) Handler
+
+// chain composes middlewares around handler. The first middleware
+// is the outermost one.
+func chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// TracingMiddleware extracts a trace context from message headers
+// and starts a consumer span.
+func TracingMiddleware(next Handler) Handler {
+	return func(ctx context.Context, msg *broker.Message) error {
+		converted := make(map[string]string, len(msg.Header))
+		for k, v := range msg.Header {
+			converted[strings.ToLower(k)] = v
+		}
+
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(converted))
+		ctx, span := otel.GetTracerProvider().Tracer(middleware.InstrumentationName).Start(ctx, "messaging.Handle")
+		defer span.End()
+
+		return next(ctx, msg)
+	}
+}
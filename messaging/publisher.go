@@ -0,0 +1,139 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package messaging provides a broker wrapper for go-micro broker.
+//
+// The messaging package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/broker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// A Publisher provides JSON based publish/subscribe over a
+// configured broker. This structure is expected to be
+// initialized automatically by fx.
+type Publisher struct {
+	broker      BrokerWithOptions
+	middlewares []Middleware
+	tracing     bool
+}
+
+// A Publisher constructor. Called automatically by fx and
+// bootstrapper.
+//
+// When tracing is enabled the span context is injected into published
+// message headers and extracted by subscribers.
+func NewPublisher(broker BrokerWithOptions, tracerConfig *config.TracerConfig) *Publisher {
+	return &Publisher{
+		broker:  broker,
+		tracing: tracerConfig != nil && tracerConfig.Tracer.Enable,
+	}
+}
+
+// Use appends middlewares applied to every handler registered via
+// Subscribe afterwards. Middlewares run in the order they are added.
+func (p *Publisher) Use(middlewares ...Middleware) *Publisher {
+	p.middlewares = append(p.middlewares, middlewares...)
+	return p
+}
+
+// Publish marshals msg to JSON and publishes it to topic.
+// A correlation ID found in ctx is passed via message headers.
+// It returns the first error encountered while marshaling or publishing.
+// Broker failures are wrapped with ErrPublishFailed.
+//
+// A successful Publish returns err == nil.
+func (p *Publisher) Publish(ctx context.Context, topic string, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	header := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		header[CorrelationIDHeader] = id
+	}
+
+	if p.tracing {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(header))
+	}
+
+	return wrapError(ErrPublishFailed, p.broker.Broker.Publish(topic, &broker.Message{
+		Header: header,
+		Body:   body,
+	}, broker.PublishContext(ctx)))
+}
+
+// Subscribe registers handler for topic with the broker's subscriber options.
+// The handler receives raw message bodies and is wrapped with
+// the publisher's middlewares.
+//
+// Returns a subscriber and the first error encountered while subscribing.
+// Broker failures are wrapped with ErrSubscribeFailed.
+func (p *Publisher) Subscribe(
+	topic string, handler func(context.Context, []byte) error,
+) (broker.Subscriber, error) {
+	// Zero-value subscribe options (memory and disabled brokers)
+	// fall back to broker defaults.
+	ctx := context.Background()
+	var subOpts []broker.SubscribeOption
+	if opts := p.broker.SubOptions; opts.Context != nil {
+		ctx = opts.Context
+		subOpts = append(subOpts, broker.SubscribeContext(ctx))
+		if opts.Queue != "" {
+			subOpts = append(subOpts, broker.Queue(opts.Queue))
+		}
+
+		if !opts.AutoAck {
+			subOpts = append(subOpts, broker.DisableAutoAck())
+		}
+	}
+
+	middlewares := []Middleware{CorrelationMiddleware}
+	if p.tracing {
+		middlewares = append(middlewares, TracingMiddleware)
+	}
+
+	middlewares = append(middlewares, p.middlewares...)
+
+	wrapped := chain(func(ctx context.Context, msg *broker.Message) error {
+		return handler(ctx, msg.Body)
+	}, middlewares...)
+
+	sub, err := p.broker.Broker.Subscribe(topic, func(e broker.Event) error {
+		if e.Message() == nil {
+			return nil
+		}
+
+		return wrapped(ctx, e.Message())
+	}, subOpts...)
+
+	return sub, wrapError(ErrSubscribeFailed, err)
+}
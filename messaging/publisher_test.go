@@ -0,0 +1,152 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package messaging
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/broker"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestPublisher returns a publisher over a connected memory broker.
+// The memory broker delivers messages synchronously on publish.
+func newTestPublisher(t *testing.T, tracerConfig *config.TracerConfig) *Publisher {
+	t.Helper()
+	b := newTestBroker(t)
+	if err := b.Connect(); err != nil {
+		t.Fatalf("could not connect a memory broker: %v", err)
+	}
+
+	t.Cleanup(func() { b.Disconnect() })
+	return NewPublisher(b, tracerConfig)
+}
+
+func TestPublisherDisconnectedBroker(t *testing.T) {
+	p := NewPublisher(newTestBroker(t), nil)
+	if err := p.Publish(context.Background(), "topic", map[string]string{"key": "value"}); !errors.Is(err, ErrPublishFailed) {
+		t.Fatalf("expected a publish failure, got %v", err)
+	}
+
+	if _, err := p.Subscribe("topic", func(ctx context.Context, body []byte) error { return nil }); !errors.Is(err, ErrSubscribeFailed) {
+		t.Fatalf("expected a subscribe failure, got %v", err)
+	}
+
+	if err := p.Publish(context.Background(), "topic", make(chan int)); err == nil || errors.Is(err, ErrPublishFailed) {
+		t.Fatalf("expected a marshaling error to be told apart from a broker failure, got %v", err)
+	}
+}
+
+func TestPublisherMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, msg *broker.Message) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, msg)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	p := newTestPublisher(t, nil).Use(record("first"), record("second"))
+	if _, err := p.Subscribe("topic", func(ctx context.Context, body []byte) error {
+		calls = append(calls, "handler")
+		return nil
+	}); err != nil {
+		t.Fatalf("could not subscribe: %v", err)
+	}
+
+	if err := p.Publish(context.Background(), "topic", "message"); err != nil {
+		t.Fatalf("could not publish: %v", err)
+	}
+
+	expected := []string{"first:before", "second:before", "handler", "second:after", "first:after"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected %v, got %v", expected, calls)
+	}
+}
+
+func TestPublisherTracePropagation(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	var tracerConfig config.TracerConfig
+	tracerConfig.Tracer.Enable = true
+	p := newTestPublisher(t, &tracerConfig)
+
+	var consumed trace.SpanContext
+	if _, err := p.Subscribe("topic", func(ctx context.Context, body []byte) error {
+		consumed = trace.SpanContextFromContext(ctx)
+		return nil
+	}); err != nil {
+		t.Fatalf("could not subscribe: %v", err)
+	}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "produce")
+	defer span.End()
+	if err := p.Publish(ctx, "topic", "message"); err != nil {
+		t.Fatalf("could not publish: %v", err)
+	}
+
+	if !consumed.IsValid() || consumed.TraceID() != span.SpanContext().TraceID() {
+		t.Fatalf("expected the consumer span to continue trace %s, got %s", span.SpanContext().TraceID(), consumed.TraceID())
+	}
+
+	if consumed.SpanID() == span.SpanContext().SpanID() {
+		t.Fatal("expected the consumer to start its own span")
+	}
+}
+
+func TestPublisherCorrelationID(t *testing.T) {
+	p := newTestPublisher(t, nil)
+
+	var consumed string
+	if _, err := p.Subscribe("topic", func(ctx context.Context, body []byte) error {
+		consumed = CorrelationIDFromContext(ctx)
+		return nil
+	}); err != nil {
+		t.Fatalf("could not subscribe: %v", err)
+	}
+
+	ctx := ContextWithCorrelationID(context.Background(), "request-id")
+	if err := p.Publish(ctx, "topic", "message"); err != nil {
+		t.Fatalf("could not publish: %v", err)
+	}
+
+	if consumed != "request-id" {
+		t.Fatalf("expected the correlation id to survive a round trip, got %q", consumed)
+	}
+}
@@ -0,0 +1,78 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestGzipHandler(contentType string, body string) http.Handler {
+	return Gzip(gzip.DefaultCompression)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		io.WriteString(w, body)
+	}))
+}
+
+func TestGzipCompressesAcceptedResponses(t *testing.T) {
+	body := strings.Repeat("metric 1\n", 100)
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rec := httptest.NewRecorder()
+	newTestGzipHandler("text/plain", body).ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected a gzip encoded response, got %q", rec.Header().Get("Content-Encoding"))
+	}
+
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("could not read a gzip response: %s", err.Error())
+	}
+
+	decoded, err := io.ReadAll(gz)
+	if err != nil || string(decoded) != body {
+		t.Fatalf("expected the decoded response to match the original body, got %v", err)
+	}
+}
+
+func TestGzipSkipsPlainClients(t *testing.T) {
+	body := strings.Repeat("metric 1\n", 100)
+	rec := httptest.NewRecorder()
+	newTestGzipHandler("text/plain", body).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != body {
+		t.Fatal("expected a plain response for a client without gzip support")
+	}
+}
+
+func TestGzipSkipsCompressedTypes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/image", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	newTestGzipHandler("image/png", "png").ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.String() != "png" {
+		t.Fatal("expected an already compressed content type to be skipped")
+	}
+}
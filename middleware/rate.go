@@ -25,6 +25,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/redis/go-redis/v9"
+	"github.com/sethvargo/go-limiter"
 	"github.com/sethvargo/go-limiter/httplimit"
 	"github.com/sethvargo/go-limiter/memorystore"
 )
@@ -32,6 +35,30 @@ import (
 // Option defines a single option.
 type Option func() httplimit.KeyFunc
 
+// RateLimiterOption defines a single ratelimiter store option.
+type RateLimiterOption func(*rateLimiterOptions)
+
+type rateLimiterOptions struct {
+	redis  redis.UniversalClient
+	logger log.Logger
+}
+
+// WithRedisStore shares ratelimiter limits across instances via redis.
+// Requests are allowed while redis is unavailable.
+func WithRedisStore(client redis.UniversalClient) RateLimiterOption {
+	return func(o *rateLimiterOptions) {
+		o.redis = client
+	}
+}
+
+// WithRateLimiterLogger sets a logger used to report ratelimiter store
+// failures.
+func WithRateLimiterLogger(logger log.Logger) RateLimiterOption {
+	return func(o *rateLimiterOptions) {
+		o.logger = logger
+	}
+}
+
 const _AllRequests = "ALL"
 
 // WithKeyFuncIP sets ratelimiter based on IP.
@@ -46,12 +73,36 @@ func WithKeyFuncAll() httplimit.KeyFunc {
 	}
 }
 
-// NewRateLimiter creates a ratelimiter middleware.
-func NewRateLimiter(limit uint64, exp time.Duration, keyFunc Option) func(next http.Handler) http.Handler {
-	store, _ := memorystore.New(&memorystore.Config{
-		Tokens:   limit,
-		Interval: exp,
-	})
+// NewRateLimiter creates a ratelimiter middleware. Uses an in-memory
+// store by default.
+func NewRateLimiter(
+	limit uint64, exp time.Duration,
+	keyFunc Option, opts ...RateLimiterOption,
+) func(next http.Handler) http.Handler {
+	var options rateLimiterOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	var store limiter.Store
+	if options.redis != nil {
+		store = newRedisLimiterStore(options.redis, options.logger, limit, exp)
+	} else {
+		store, _ = memorystore.New(&memorystore.Config{
+			Tokens:   limit,
+			Interval: exp,
+		})
+	}
+
 	limiter, _ := httplimit.NewMiddleware(store, keyFunc())
 	return limiter.Handle
 }
+
+// NewRedisRateLimiter creates a distributed ratelimiter middleware
+// backed by redis.
+func NewRedisRateLimiter(
+	client redis.UniversalClient, limit uint64,
+	exp time.Duration, keyFunc Option,
+) func(next http.Handler) http.Handler {
+	return NewRateLimiter(limit, exp, keyFunc, WithRedisStore(client))
+}
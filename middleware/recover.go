@@ -0,0 +1,59 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package middleware provides http middlewares
+//
+// The middleware package's functions get added to http services automatically.
+package middleware
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+)
+
+// Recover creates a new panic recovery middleware. Logs recovered panics
+// with a stack trace and responds with 500.
+func Recover(logger log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				if logger != nil {
+					logger.Errorf(
+						"recovered panic on [%s] route %s: %v\n%s",
+						r.Method, r.URL.Path, rec, debug.Stack(),
+					)
+				}
+
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package middleware provides http middlewares
+//
+// The middleware package's functions get added to http services automatically.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// _redisTokenBucket takes a token from a bucket stored as a redis hash.
+// Tokens are refilled continuously over the interval. Per-key limits set
+// via Set are kept in a separate non-expiring hash and take precedence
+// over default ones. Only the bucket state expires once it is fully refilled.
+//
+// Returns {ok, tokens, remaining, reset in milliseconds}.
+var _redisTokenBucket = redis.NewScript(`
+local now = redis.call('TIME')
+now = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+
+local conf = redis.call('HMGET', KEYS[2], 'max', 'interval')
+local max = tonumber(conf[1]) or tonumber(ARGV[1])
+local interval = tonumber(conf[2]) or tonumber(ARGV[2])
+
+local data = redis.call('HMGET', KEYS[1], 'avail', 'ts')
+local avail = tonumber(data[1]) or max
+local ts = tonumber(data[2]) or now
+
+if now > ts then
+	avail = math.min(max, avail + (now - ts) * max / interval)
+end
+
+local ok = 0
+if avail >= 1 then
+	avail = avail - 1
+	ok = 1
+end
+
+redis.call('HSET', KEYS[1], 'avail', tostring(avail), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], interval * 2)
+
+local reset = now + math.ceil((max - avail) * interval / max)
+return {ok, max, math.floor(avail), reset}
+`)
+
+// redisLimiterStore is a go-limiter store backed by redis.
+type redisLimiterStore struct {
+	client   redis.UniversalClient
+	logger   log.Logger
+	tokens   uint64
+	interval time.Duration
+}
+
+func newRedisLimiterStore(
+	client redis.UniversalClient, logger log.Logger,
+	tokens uint64, interval time.Duration,
+) *redisLimiterStore {
+	if logger == nil {
+		logger = log.EmptyLogger{}
+	}
+
+	return &redisLimiterStore{
+		client:   client,
+		logger:   logger,
+		tokens:   tokens,
+		interval: interval,
+	}
+}
+
+// Take takes a token from the key's bucket. Allows the request
+// if redis is unavailable.
+func (s *redisLimiterStore) Take(ctx context.Context, key string) (uint64, uint64, uint64, bool, error) {
+	res, err := _redisTokenBucket.Run(
+		ctx, s.client, []string{redisLimiterKey(key), redisLimiterConfigKey(key)},
+		s.tokens, s.interval.Milliseconds(),
+	).Int64Slice()
+	if err == nil && len(res) != 4 {
+		err = fmt.Errorf("unexpected token bucket result length %d", len(res))
+	}
+
+	if err != nil {
+		s.logger.Warnf("could not take a ratelimiter token for %s, allowing the request: %s", key, err.Error())
+		return s.tokens, s.tokens, uint64(time.Now().Add(s.interval).UnixNano()), true, nil
+	}
+
+	reset := uint64(time.UnixMilli(res[3]).UnixNano())
+	return uint64(res[1]), uint64(res[2]), reset, res[0] == 1, nil
+}
+
+// Get returns the key's configured and available tokens.
+func (s *redisLimiterStore) Get(ctx context.Context, key string) (uint64, uint64, error) {
+	max, err := s.client.HGet(ctx, redisLimiterConfigKey(key), "max").Result()
+	if err != nil && err != redis.Nil {
+		return 0, 0, err
+	}
+
+	avail, err := s.client.HGet(ctx, redisLimiterKey(key), "avail").Result()
+	if err != nil && err != redis.Nil {
+		return 0, 0, err
+	}
+
+	tokens, remaining := s.tokens, s.tokens
+	if v, err := strconv.ParseUint(max, 10, 64); err == nil {
+		tokens, remaining = v, v
+	}
+
+	if v, err := strconv.ParseFloat(avail, 64); err == nil {
+		remaining = uint64(v)
+	}
+
+	return tokens, remaining, nil
+}
+
+// Set configures the key's tokens and interval. The configuration does
+// not expire and the key's bucket is refilled.
+func (s *redisLimiterStore) Set(ctx context.Context, key string, tokens uint64, interval time.Duration) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisLimiterConfigKey(key), "max", tokens, "interval", interval.Milliseconds())
+		pipe.Del(ctx, redisLimiterKey(key))
+		return nil
+	})
+
+	return err
+}
+
+// Burst adds tokens to the key's bucket.
+func (s *redisLimiterStore) Burst(ctx context.Context, key string, tokens uint64) error {
+	return s.client.HIncrByFloat(ctx, redisLimiterKey(key), "avail", float64(tokens)).Err()
+}
+
+// Close is a noop since the redis client is managed by the caller.
+func (s *redisLimiterStore) Close(ctx context.Context) error {
+	return nil
+}
+
+// redisLimiterKey and redisLimiterConfigKey share a hash tag so that both
+// keys land in the same cluster slot.
+func redisLimiterKey(key string) string {
+	return "ratelimiter:{" + key + "}"
+}
+
+func redisLimiterConfigKey(key string) string {
+	return "ratelimiter:config:{" + key + "}"
+}
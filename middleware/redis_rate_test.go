@@ -0,0 +1,79 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisLimiterStore(t *testing.T, tokens uint64, interval time.Duration) (*redisLimiterStore, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return newRedisLimiterStore(client, nil, tokens, interval), mr
+}
+
+func TestRedisLimiterStoreTake(t *testing.T) {
+	store, _ := newTestRedisLimiterStore(t, 2, time.Minute)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, _, _, ok, err := store.Take(ctx, "key"); err != nil || !ok {
+			t.Fatalf("expected take %d to be allowed, got %v, %v", i, ok, err)
+		}
+	}
+
+	if _, _, _, ok, _ := store.Take(ctx, "key"); ok {
+		t.Fatal("expected a take over the limit to be rejected")
+	}
+}
+
+func TestRedisLimiterStoreSetSurvivesExpiry(t *testing.T) {
+	store, mr := newTestRedisLimiterStore(t, 10, time.Second)
+	ctx := context.Background()
+	if err := store.Set(ctx, "key", 1, time.Minute); err != nil {
+		t.Fatalf("could not set limits: %s", err.Error())
+	}
+
+	if _, _, _, ok, _ := store.Take(ctx, "key"); !ok {
+		t.Fatal("expected the first take to be allowed")
+	}
+
+	mr.FastForward(5 * time.Second)
+	if !mr.Exists(redisLimiterConfigKey("key")) {
+		t.Fatal("expected the key limits not to expire")
+	}
+
+	tokens, _, _, ok, _ := store.Take(ctx, "key")
+	if ok || tokens != 1 {
+		t.Fatalf("expected the key limits to be kept, got %d tokens and %v", tokens, ok)
+	}
+}
+
+func TestRedisLimiterStoreUnavailable(t *testing.T) {
+	store, mr := newTestRedisLimiterStore(t, 1, time.Minute)
+	mr.Close()
+	if _, _, _, ok, err := store.Take(context.Background(), "key"); err != nil || !ok {
+		t.Fatalf("expected requests to be allowed while redis is down, got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,142 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package middleware provides http middlewares
+//
+// The middleware package's functions get added to http services automatically.
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// RequestLoggerOption defines a single request logger option.
+type RequestLoggerOption func(*requestLoggerOptions)
+
+type requestLoggerOptions struct {
+	debug      bool
+	debugPaths map[string]struct{}
+}
+
+// WithRequestLogDebug demotes all request logs to Debug level.
+func WithRequestLogDebug() RequestLoggerOption {
+	return func(o *requestLoggerOptions) {
+		o.debug = true
+	}
+}
+
+// WithRequestLogDebugPaths demotes request logs of the paths provided
+// to Debug level. Useful for high-traffic endpoints.
+func WithRequestLogDebugPaths(paths ...string) RequestLoggerOption {
+	return func(o *requestLoggerOptions) {
+		for _, path := range paths {
+			o.debugPaths[path] = struct{}{}
+		}
+	}
+}
+
+// statusWriter records a response status code.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		if w.status == 0 {
+			w.status = http.StatusSwitchingProtocols
+		}
+
+		return h.Hijack()
+	}
+
+	return nil, nil, http.ErrNotSupported
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// RequestLogger creates a new access logging middleware. Logs method, path,
+// status, duration, request id and real ip at Info level by default.
+func RequestLogger(logger log.Logger, opts ...RequestLoggerOption) func(http.Handler) http.Handler {
+	options := requestLoggerOptions{debugPaths: make(map[string]struct{})}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if logger == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			if sw.status == 0 {
+				sw.status = http.StatusOK
+			}
+
+			logf := logger.Infof
+			if _, ok := options.debugPaths[r.URL.Path]; ok || options.debug {
+				logf = logger.Debugf
+			}
+
+			logf(
+				"[%s] %s %d %s request_id=%s ip=%s",
+				r.Method, r.URL.Path, sw.status, time.Since(start),
+				chimiddleware.GetReqID(r.Context()), r.RemoteAddr,
+			)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// hijackRecorder is a hijackable response recorder.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func TestRequestLoggerFlush(t *testing.T) {
+	logger := &recordLogger{}
+	handler := RequestLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected the response writer to be a flusher")
+		}
+
+		f.Flush()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if !rec.Flushed {
+		t.Fatal("expected the flush to reach the underlying writer")
+	}
+
+	if len(logger.infos) != 1 || !strings.Contains(logger.infos[0], "/events 200") {
+		t.Fatalf("expected a 200 request log, got %v", logger.infos)
+	}
+}
+
+func TestRequestLoggerHijack(t *testing.T) {
+	handler := RequestLogger(&recordLogger{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("expected the response writer to be a hijacker")
+		}
+
+		if _, _, err := h.Hijack(); err != nil {
+			t.Fatalf("could not hijack a connection: %v", err)
+		}
+	}))
+
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	if !rec.hijacked {
+		t.Fatal("expected the hijack to reach the underlying writer")
+	}
+
+	sw := &statusWriter{ResponseWriter: httptest.NewRecorder()}
+	if _, _, err := sw.Hijack(); !errors.Is(err, http.ErrNotSupported) {
+		t.Fatalf("expected a not supported error, got %v", err)
+	}
+}
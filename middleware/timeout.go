@@ -26,9 +26,11 @@ import (
 	"time"
 )
 
-// Timeout sets up a timeout request handler.
+// Timeout sets up a timeout request handler. Cancels the request context
+// after timeout and responds with 503. Handlers must respect the request
+// context to stop in-flight work.
 func Timeout(timeout time.Duration) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		return http.TimeoutHandler(http.HandlerFunc(next.ServeHTTP), timeout, "request timeout")
+		return http.TimeoutHandler(http.HandlerFunc(next.ServeHTTP), timeout, "request timeout exceeded")
 	}
 }
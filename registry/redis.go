@@ -0,0 +1,400 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package registry provides a go-micro compatible registry implementation
+//
+// The registry package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-micro.dev/v4/registry"
+)
+
+const (
+	redisPrefix   = "micro:registry:"
+	redisServices = redisPrefix + "services"
+	redisEvents   = redisPrefix + "events"
+)
+
+// _redisExpireNode removes a node only if it has not been re-registered
+// since it was read. Returns the number of removed nodes.
+var _redisExpireNode = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call('HDEL', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type redisAuthKey struct{}
+
+type redisAuth struct {
+	username string
+	password string
+}
+
+// RedisAuth sets redis registry username and password.
+func RedisAuth(username, password string) registry.Option {
+	return func(o *registry.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+
+		o.Context = context.WithValue(o.Context, redisAuthKey{}, redisAuth{
+			username: username,
+			password: password,
+		})
+	}
+}
+
+// redisNode is a single service node stored in a service hash.
+type redisNode struct {
+	Service   *registry.Service `json:"service"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type redisRegistry struct {
+	sync.RWMutex
+	options registry.Options
+	client  redis.UniversalClient
+}
+
+// NewRedisRegistry creates a redis backed go-micro registry.
+//
+// Every service is stored as a hash of nodes. Nodes registered with a TTL
+// are considered expired after it elapses and are removed lazily on reads.
+// Changes, including expired nodes' removal, are published via redis
+// pub/sub to watchers.
+func NewRedisRegistry(opts ...registry.Option) registry.Registry {
+	r := &redisRegistry{
+		options: registry.Options{
+			Timeout: 5 * time.Second,
+		},
+	}
+
+	r.configure(opts...)
+	return r
+}
+
+func (r *redisRegistry) configure(opts ...registry.Option) {
+	for _, o := range opts {
+		o(&r.options)
+	}
+
+	addrs := r.options.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{"127.0.0.1:6379"}
+	}
+
+	ropts := &redis.UniversalOptions{
+		Addrs:        addrs,
+		DialTimeout:  r.options.Timeout,
+		ReadTimeout:  r.options.Timeout,
+		WriteTimeout: r.options.Timeout,
+	}
+
+	if r.options.Context != nil {
+		if auth, ok := r.options.Context.Value(redisAuthKey{}).(redisAuth); ok {
+			ropts.Username = auth.username
+			ropts.Password = auth.password
+		}
+	}
+
+	if r.options.Secure || r.options.TLSConfig != nil {
+		ropts.TLSConfig = r.options.TLSConfig
+		if ropts.TLSConfig == nil {
+			ropts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+	}
+
+	if r.client != nil {
+		r.client.Close()
+	}
+
+	r.client = redis.NewUniversalClient(ropts)
+}
+
+func (r *redisRegistry) Init(opts ...registry.Option) error {
+	r.Lock()
+	defer r.Unlock()
+	r.configure(opts...)
+	return nil
+}
+
+func (r *redisRegistry) Options() registry.Options {
+	return r.options
+}
+
+func (r *redisRegistry) Register(s *registry.Service, opts ...registry.RegisterOption) error {
+	if len(s.Nodes) == 0 {
+		return errors.New("require at least one node")
+	}
+
+	var options registry.RegisterOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.Timeout)
+	defer cancel()
+
+	var expiresAt time.Time
+	if options.TTL > 0 {
+		expiresAt = time.Now().Add(options.TTL)
+	}
+
+	fields := make(map[string]any, len(s.Nodes))
+	for _, node := range s.Nodes {
+		buf, err := json.Marshal(redisNode{
+			Service:   withNodes(s, node),
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		fields[node.Id] = buf
+	}
+
+	if _, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisServiceKey(s.Name), fields)
+		pipe.SAdd(ctx, redisServices, s.Name)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return r.publish(ctx, "update", s)
+}
+
+func (r *redisRegistry) Deregister(s *registry.Service, opts ...registry.DeregisterOption) error {
+	if len(s.Nodes) == 0 {
+		return errors.New("require at least one node")
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.Timeout)
+	defer cancel()
+
+	ids := make([]string, 0, len(s.Nodes))
+	for _, node := range s.Nodes {
+		ids = append(ids, node.Id)
+	}
+
+	if err := r.client.HDel(ctx, redisServiceKey(s.Name), ids...).Err(); err != nil {
+		return err
+	}
+
+	return r.publish(ctx, "delete", s)
+}
+
+func (r *redisRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.Timeout)
+	defer cancel()
+
+	return r.getService(ctx, name)
+}
+
+func (r *redisRegistry) getService(ctx context.Context, name string) ([]*registry.Service, error) {
+	values, err := r.client.HGetAll(ctx, redisServiceKey(name)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []redisExpiredNode
+	versions := make(map[string]*registry.Service)
+	for id, value := range values {
+		var node redisNode
+		if err := json.Unmarshal([]byte(value), &node); err != nil || node.Service == nil {
+			continue
+		}
+
+		if !node.ExpiresAt.IsZero() && node.ExpiresAt.Before(time.Now()) {
+			expired = append(expired, redisExpiredNode{id: id, value: value, service: node.Service})
+			continue
+		}
+
+		s, ok := versions[node.Service.Version]
+		if !ok {
+			s = withNodes(node.Service)
+			versions[s.Version] = s
+		}
+
+		s.Nodes = append(s.Nodes, node.Service.Nodes...)
+	}
+
+	for _, node := range expired {
+		r.expire(ctx, name, node)
+	}
+
+	if len(versions) == 0 {
+		return nil, registry.ErrNotFound
+	}
+
+	services := make([]*registry.Service, 0, len(versions))
+	for _, s := range versions {
+		services = append(services, s)
+	}
+
+	return services, nil
+}
+
+// redisExpiredNode is an expired node along with its raw hash value.
+type redisExpiredNode struct {
+	id      string
+	value   string
+	service *registry.Service
+}
+
+// expire removes an expired node and notifies watchers. Concurrent
+// readers publish a single delete event per node.
+func (r *redisRegistry) expire(ctx context.Context, name string, node redisExpiredNode) {
+	removed, err := _redisExpireNode.Run(ctx, r.client, []string{redisServiceKey(name)}, node.id, node.value).Int()
+	if err == nil && removed > 0 {
+		r.publish(ctx, "delete", node.service)
+	}
+}
+
+func (r *redisRegistry) ListServices(opts ...registry.ListOption) ([]*registry.Service, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.Timeout)
+	defer cancel()
+
+	names, err := r.client.SMembers(ctx, redisServices).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var services []*registry.Service
+	for _, name := range names {
+		found, err := r.getService(ctx, name)
+		if errors.Is(err, registry.ErrNotFound) {
+			r.client.SRem(ctx, redisServices, name)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, found...)
+	}
+
+	return services, nil
+}
+
+func (r *redisRegistry) Watch(opts ...registry.WatchOption) (registry.Watcher, error) {
+	var options registry.WatchOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.Timeout)
+	defer cancel()
+
+	sub := r.client.Subscribe(context.Background(), redisEvents)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+
+	return &redisWatcher{
+		service: options.Service,
+		sub:     sub,
+		ch:      sub.Channel(),
+	}, nil
+}
+
+func (r *redisRegistry) String() string {
+	return "redis"
+}
+
+func (r *redisRegistry) publish(ctx context.Context, action string, s *registry.Service) error {
+	buf, err := json.Marshal(registry.Result{Action: action, Service: s})
+	if err != nil {
+		return err
+	}
+
+	return r.client.Publish(ctx, redisEvents, buf).Err()
+}
+
+type redisWatcher struct {
+	service string
+	sub     *redis.PubSub
+	ch      <-chan *redis.Message
+}
+
+func (w *redisWatcher) Next() (*registry.Result, error) {
+	for msg := range w.ch {
+		var result registry.Result
+		if err := json.Unmarshal([]byte(msg.Payload), &result); err != nil || result.Service == nil {
+			continue
+		}
+
+		if w.service != "" && w.service != result.Service.Name {
+			continue
+		}
+
+		return &result, nil
+	}
+
+	return nil, registry.ErrWatcherStopped
+}
+
+func (w *redisWatcher) Stop() {
+	w.sub.Close()
+}
+
+// redisServiceKey returns a service hash key. Service hashes have their own
+// namespace so that service names never collide with registry internal keys.
+func redisServiceKey(name string) string {
+	return redisPrefix + "service:" + name
+}
+
+// withNodes copies a service replacing its nodes.
+func withNodes(s *registry.Service, nodes ...*registry.Node) *registry.Service {
+	return &registry.Service{
+		Name:      s.Name,
+		Version:   s.Version,
+		Metadata:  s.Metadata,
+		Endpoints: s.Endpoints,
+		Nodes:     nodes,
+	}
+}
@@ -0,0 +1,112 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go-micro.dev/v4/registry"
+)
+
+func newTestRedisRegistry(t *testing.T) (registry.Registry, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return NewRedisRegistry(registry.Addrs(mr.Addr())), mr
+}
+
+func newTestService(name, id string) *registry.Service {
+	return &registry.Service{
+		Name:    name,
+		Version: "latest",
+		Nodes:   []*registry.Node{{Id: id, Address: "127.0.0.1:8080"}},
+	}
+}
+
+func TestNewRegistryRedis(t *testing.T) {
+	config := newTestRegistryConfig(5)
+	config.Registry.TLS = false
+	if r := newRegistry(config); r.String() != "redis" {
+		t.Fatalf("expected a redis registry for type 5, got %s", r.String())
+	}
+}
+
+func TestRedisRegistryRegister(t *testing.T) {
+	r, mr := newTestRedisRegistry(t)
+	for _, name := range []string{"services", "events", "docs"} {
+		if err := r.Register(newTestService(name, name+"-1")); err != nil {
+			t.Fatalf("could not register %s: %s", name, err.Error())
+		}
+	}
+
+	if !mr.Exists(redisServiceKey("services")) || !mr.Exists(redisServiceKey("events")) {
+		t.Fatal("expected services to be stored in their own namespace")
+	}
+
+	services, err := r.ListServices()
+	if err != nil {
+		t.Fatalf("could not list services: %s", err.Error())
+	}
+
+	if len(services) != 3 {
+		t.Fatalf("expected 3 services, got %d", len(services))
+	}
+
+	if err := r.Deregister(newTestService("docs", "docs-1")); err != nil {
+		t.Fatalf("could not deregister a service: %s", err.Error())
+	}
+
+	if _, err := r.GetService("docs"); !errors.Is(err, registry.ErrNotFound) {
+		t.Fatalf("expected a deregistered service not to be found, got %v", err)
+	}
+}
+
+func TestRedisRegistryExpiredNodeEvent(t *testing.T) {
+	r, _ := newTestRedisRegistry(t)
+	if err := r.Register(newTestService("docs", "docs-1"), registry.RegisterTTL(10*time.Millisecond)); err != nil {
+		t.Fatalf("could not register a service: %s", err.Error())
+	}
+
+	w, err := r.Watch(registry.WatchService("docs"))
+	if err != nil {
+		t.Fatalf("could not watch services: %s", err.Error())
+	}
+	defer w.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := r.GetService("docs"); !errors.Is(err, registry.ErrNotFound) {
+		t.Fatalf("expected an expired service not to be found, got %v", err)
+	}
+
+	results := make(chan *registry.Result, 1)
+	go func() {
+		res, _ := w.Next()
+		results <- res
+	}()
+
+	select {
+	case res := <-results:
+		if res == nil || res.Action != "delete" || res.Service.Nodes[0].Id != "docs-1" {
+			t.Fatalf("expected a delete event for the expired node, got %+v", res)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a delete event for the expired node")
+	}
+}
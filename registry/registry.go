@@ -24,40 +24,91 @@
 package registry
 
 import (
+	"crypto/tls"
+
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/go-micro/plugins/v4/registry/consul"
 	"github.com/go-micro/plugins/v4/registry/etcd"
 	"github.com/go-micro/plugins/v4/registry/kubernetes"
 	"github.com/go-micro/plugins/v4/registry/mdns"
+	"github.com/hashicorp/consul/api"
 	"go-micro.dev/v4/registry"
 	"go-micro.dev/v4/registry/cache"
 )
 
 // NewRegistry looks up envs and configures respective registries based on those variables. Defaults to memory
 func NewRegistry(config *config.RegistryConfig) registry.Registry {
+	return cache.New(newRegistry(config), cache.WithTTL(config.Registry.CacheTTL))
+}
+
+// newRegistry selects a registry implementation by the configured type.
+func newRegistry(config *config.RegistryConfig) registry.Registry {
 	var r registry.Registry
+	opts := registryOptions(config)
 	switch config.Registry.Type {
 	case 1:
-		r = kubernetes.NewRegistry(
-			registry.Addrs(config.Registry.Addresses...),
-		)
+		r = kubernetes.NewRegistry(opts...)
 	case 2:
-		r = consul.NewRegistry(
-			registry.Addrs(config.Registry.Addresses...),
-		)
+		r = consul.NewRegistry(opts...)
 	case 3:
-		r = etcd.NewRegistry(
-			registry.Addrs(config.Registry.Addresses...),
-		)
+		r = etcd.NewRegistry(opts...)
+	case 5:
+		r = NewRedisRegistry(opts...)
 	case 4:
-		r = mdns.NewRegistry(
-			registry.Addrs(config.Registry.Addresses...),
-		)
+		r = mdns.NewRegistry(opts...)
 	default:
-		r = mdns.NewRegistry(
-			registry.Addrs(config.Registry.Addresses...),
-		)
+		r = mdns.NewRegistry(opts...)
+	}
+
+	return r
+}
+
+// registryOptions builds options for the configured registry type.
+// Credentials and TLS are ignored by kubernetes and mdns registries.
+func registryOptions(config *config.RegistryConfig) []registry.Option {
+	opts := []registry.Option{registry.Addrs(config.Registry.Addresses...)}
+	switch config.Registry.Type {
+	case 2:
+		opts = append(opts, secureOptions(config)...)
+		opts = append(opts, consul.Config(consulConfig(config)))
+	case 3:
+		opts = append(opts, secureOptions(config)...)
+		if config.Registry.Username != "" {
+			opts = append(opts, etcd.Auth(config.Registry.Username, config.Registry.Password))
+		}
+	case 5:
+		opts = append(opts, secureOptions(config)...)
+		if config.Registry.Username != "" || config.Registry.Password != "" {
+			opts = append(opts, RedisAuth(config.Registry.Username, config.Registry.Password))
+		}
 	}
 
-	return cache.New(r, cache.WithTTL(config.Registry.CacheTTL))
+	return opts
+}
+
+// consulConfig builds a consul client configuration with ACL token
+// and basic auth credentials.
+func consulConfig(config *config.RegistryConfig) *api.Config {
+	cconfig := api.DefaultConfig()
+	cconfig.Token = config.Registry.Token
+	if config.Registry.Username != "" {
+		cconfig.HttpAuth = &api.HttpBasicAuth{
+			Username: config.Registry.Username,
+			Password: config.Registry.Password,
+		}
+	}
+
+	return cconfig
+}
+
+// secureOptions returns TLS registry options when TLS is enabled.
+func secureOptions(config *config.RegistryConfig) []registry.Option {
+	if !config.Registry.TLS {
+		return nil
+	}
+
+	return []registry.Option{
+		registry.Secure(true),
+		registry.TLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+	}
 }
@@ -0,0 +1,111 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/registry"
+)
+
+func newTestRegistryConfig(registryType int) *config.RegistryConfig {
+	var config config.RegistryConfig
+	config.Registry.Type = registryType
+	config.Registry.Addresses = []string{"127.0.0.1:1234"}
+	config.Registry.Username = "user"
+	config.Registry.Password = "password"
+	config.Registry.Token = "token"
+	config.Registry.TLS = true
+	return &config
+}
+
+func applyRegistryOptions(config *config.RegistryConfig) registry.Options {
+	var options registry.Options
+	for _, o := range registryOptions(config) {
+		o(&options)
+	}
+
+	return options
+}
+
+func TestRegistryOptionsSecure(t *testing.T) {
+	for name, registryType := range map[string]int{"consul": 2, "etcd": 3, "redis": 5} {
+		t.Run(name, func(t *testing.T) {
+			options := applyRegistryOptions(newTestRegistryConfig(registryType))
+			if !reflect.DeepEqual(options.Addrs, []string{"127.0.0.1:1234"}) {
+				t.Fatalf("expected addresses to be threaded, got %v", options.Addrs)
+			}
+
+			if !options.Secure || options.TLSConfig == nil {
+				t.Fatal("expected tls options to be threaded")
+			}
+
+			if options.Context == nil {
+				t.Fatal("expected credentials to be threaded")
+			}
+		})
+	}
+}
+
+func TestRegistryOptionsIgnoreCredentials(t *testing.T) {
+	for name, registryType := range map[string]int{"kubernetes": 1, "mdns": 4} {
+		t.Run(name, func(t *testing.T) {
+			options := applyRegistryOptions(newTestRegistryConfig(registryType))
+			if !reflect.DeepEqual(options.Addrs, []string{"127.0.0.1:1234"}) {
+				t.Fatalf("expected addresses to be threaded, got %v", options.Addrs)
+			}
+
+			if options.Secure || options.TLSConfig != nil || options.Context != nil {
+				t.Fatal("expected tls and credentials to be ignored")
+			}
+		})
+	}
+}
+
+func TestRegistryOptionsInsecure(t *testing.T) {
+	config := newTestRegistryConfig(3)
+	config.Registry.Username = ""
+	config.Registry.TLS = false
+
+	options := applyRegistryOptions(config)
+	if options.Secure || options.TLSConfig != nil || options.Context != nil {
+		t.Fatal("expected no tls and credentials options")
+	}
+}
+
+func TestConsulConfigCredentials(t *testing.T) {
+	cconfig := consulConfig(newTestRegistryConfig(2))
+	if cconfig.Token != "token" {
+		t.Fatalf("expected the consul token to be threaded, got %q", cconfig.Token)
+	}
+
+	if cconfig.HttpAuth == nil || cconfig.HttpAuth.Username != "user" || cconfig.HttpAuth.Password != "password" {
+		t.Fatalf("expected consul basic auth to be threaded, got %+v", cconfig.HttpAuth)
+	}
+}
+
+func TestRedisAuthOption(t *testing.T) {
+	options := applyRegistryOptions(newTestRegistryConfig(5))
+	auth, ok := options.Context.Value(redisAuthKey{}).(redisAuth)
+	if !ok || auth.username != "user" || auth.password != "password" {
+		t.Fatalf("expected redis credentials to be threaded, got %+v", auth)
+	}
+}
@@ -25,6 +25,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
 	"net/http"
 	"strings"
@@ -40,6 +41,7 @@ import (
 	"github.com/go-micro/plugins/v4/wrapper/breaker/hystrix"
 	"github.com/go-micro/plugins/v4/wrapper/select/roundrobin"
 	"github.com/go-micro/plugins/v4/wrapper/trace/opentelemetry"
+	"github.com/redis/go-redis/v9"
 	"go-micro.dev/v4"
 	"go-micro.dev/v4/cache"
 	"go-micro.dev/v4/client"
@@ -47,6 +49,7 @@ import (
 	"go-micro.dev/v4/server"
 	"go.opentelemetry.io/otel"
 	oteltrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
 )
 
 // ServerEngine provides main ways of interactions with
@@ -62,8 +65,9 @@ type ServerEngine interface {
 // if provided to a bootstrapper instance.
 //
 // Returns a fully configured and ready to use http based micro.Service.
-// Panics on any error.
+// Panics on any error. The ratelimiter's redis client, if any, is closed on stop.
 func NewService(
+	lifecycle fx.Lifecycle,
 	engine ServerEngine,
 	client client.Client,
 	registry registry.Registry,
@@ -80,9 +84,7 @@ func NewService(
 		log.Fatalf("could not initialize a new broker instance: %s", err.Error())
 	}
 
-	if err := broker.Broker.Connect(); err != nil {
-		log.Fatalf("broker connection error: %s", err.Error())
-	}
+	// The broker is connected and disconnected by the bootstrapper lifecycle.
 
 	hystrix.ConfigureDefault(resilience.BuildHystrixCommandConfig(resilienceConfig))
 
@@ -100,7 +102,6 @@ func NewService(
 		micro.Client(client),
 		micro.WrapClient(
 			roundrobin.NewClientWrapper(),
-			hystrix.NewClientWrapper(),
 		),
 		micro.WrapCall(opentelemetry.NewCallWrapper(opentelemetry.WithTraceProvider(otel.GetTracerProvider()))),
 		micro.RegisterTTL(30*time.Second),
@@ -121,12 +122,17 @@ func NewService(
 		}),
 	)
 
+	limiterOpts := []middleware.RateLimiterOption{middleware.WithRateLimiterLogger(logger)}
+	if rclient := newRateLimiterRedis(lifecycle, resilienceConfig.Resilience.RateLimiter, logger); rclient != nil {
+		limiterOpts = append(limiterOpts, middleware.WithRedisStore(rclient))
+	}
+
 	if resilienceConfig.Resilience.RateLimiter.IPLimit > 0 {
-		engine.ApplyMiddleware(middleware.NewRateLimiter(resilienceConfig.Resilience.RateLimiter.IPLimit, 1*time.Second, middleware.WithKeyFuncIP))
+		engine.ApplyMiddleware(middleware.NewRateLimiter(resilienceConfig.Resilience.RateLimiter.IPLimit, 1*time.Second, middleware.WithKeyFuncIP, limiterOpts...))
 	}
 
 	if resilienceConfig.Resilience.RateLimiter.Limit > 0 {
-		engine.ApplyMiddleware(middleware.NewRateLimiter(resilienceConfig.Resilience.RateLimiter.Limit, 1*time.Second, middleware.WithKeyFuncAll))
+		engine.ApplyMiddleware(middleware.NewRateLimiter(resilienceConfig.Resilience.RateLimiter.Limit, 1*time.Second, middleware.WithKeyFuncAll, limiterOpts...))
 	}
 
 	engine.ApplyMiddleware(
@@ -138,6 +144,10 @@ func NewService(
 		middleware.Cors(corsConfig.CORS.AllowedOrigins, corsConfig.CORS.AllowedMethods, corsConfig.CORS.AllowedHeaders, corsConfig.CORS.AllowCredentials),
 	)
 
+	if serverConfig.RequestTimeout > 0 {
+		engine.ApplyMiddleware(middleware.Timeout(serverConfig.RequestTimeout))
+	}
+
 	if tracerConfig.Tracer.Enable {
 		engine.ApplyMiddleware(
 			middleware.TracePropagationMiddleware,
@@ -154,3 +164,44 @@ func NewService(
 
 	return service
 }
+
+// newRateLimiterRedis builds a redis client shared by ratelimiters when a
+// redis address is configured. Returns nil otherwise.
+//
+// The client is pinged on start and closed on stop. An unreachable redis
+// does not prevent the service from starting since ratelimiters allow
+// requests while redis is unavailable.
+func newRateLimiterRedis(
+	lifecycle fx.Lifecycle, rconf config.RateLimiterConfig, logger plog.Logger,
+) *redis.Client {
+	if rconf.RedisAddress == "" {
+		return nil
+	}
+
+	opts := &redis.Options{
+		Addr:     rconf.RedisAddress,
+		Username: rconf.RedisUsername,
+		Password: rconf.RedisPassword,
+		DB:       rconf.RedisDatabase,
+	}
+
+	if rconf.RedisTLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(opts)
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := client.Ping(ctx).Err(); err != nil {
+				logger.Warnf("could not reach ratelimiter redis at %s: %s", rconf.RedisAddress, err.Error())
+			}
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return client.Close()
+		},
+	})
+
+	return client
+}
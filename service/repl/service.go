@@ -24,35 +24,73 @@
 package repl
 
 import (
+	"compress/gzip"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/middleware"
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/storage"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/hellofresh/health-go/v5"
 	"github.com/justinas/alice"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
 )
 
 // An http REPL server constructor. Called automatically by fx
 // and bootstrapper.
 //
 // Returns a fully configured and ready to use http repl server.
+// Exposes /livez which succeeds while the process is up and /readyz
+// which runs the storage check along with dependency checks provided.
+// The health path (/health by default) is kept as an alias of /readyz.
+//
+// Additional checks are collected by fx from the "health_checks" group,
+// e.g. a mongo ping check:
+//
+//	fx.Provide(fx.Annotate(
+//		func(store storage.RefinedStore) health.Config {
+//			return health.Config{Name: "mongo", Timeout: 3 * time.Second, Check: store.Ping}
+//		},
+//		fx.ResultTags(`group:"health_checks"`),
+//	))
+//
+// No additional checks are registered when the group is empty.
 func NewService(
 	replConfig *config.ServerConfig,
 	corsConfig *config.CORSConfig,
+	logger log.Logger,
+	store storage.RefinedStore,
+	checks ...health.Config,
 ) *http.Server {
 	mux := http.NewServeMux()
-	h, _ := health.New(health.WithComponent(health.Component{
+	component := health.WithComponent(health.Component{
 		Name:    fmt.Sprintf("%s:%s", replConfig.Namespace, replConfig.Name),
-		Version: fmt.Sprintf("v%d", replConfig.Version),
-	}))
+		Version: fmt.Sprintf("v%s", replConfig.Version),
+	})
 
-	mux.Handle("/metrics", promhttp.Handler())
-	mux.Handle("/health", h.Handler())
+	live, _ := health.New(component)
+	ready, _ := health.New(
+		component,
+		health.WithChecks(append([]health.Config{{
+			Name:    fmt.Sprintf("storage:%s", store.String()),
+			Timeout: 3 * time.Second,
+			Check:   store.Ping,
+		}}, checks...)...),
+	)
+
+	mux.Handle(replConfig.MetricsPath, promhttp.Handler())
+	mux.Handle("/buildinfo", newBuildInfoHandler(replConfig.Version))
+	mux.Handle("/livez", live.Handler())
+	mux.Handle("/readyz", ready.Handler())
+	if replConfig.HealthPath != "/readyz" {
+		mux.Handle(replConfig.HealthPath, ready.Handler())
+	}
 
 	if replConfig.Debug {
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
@@ -62,16 +100,49 @@ func NewService(
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
+	chain := alice.New(
+		middleware.Recover(logger),
+		chimiddleware.RealIP,
+		middleware.NewRateLimiter(1000, 1*time.Second, middleware.WithKeyFuncAll),
+		chimiddleware.RequestID,
+		middleware.RequestLogger(logger, middleware.WithRequestLogDebugPaths(
+			replConfig.MetricsPath, replConfig.HealthPath, "/livez", "/readyz",
+		)),
+		middleware.Cors(corsConfig.CORS.AllowedOrigins, corsConfig.CORS.AllowedMethods, corsConfig.CORS.AllowedHeaders, corsConfig.CORS.AllowCredentials),
+		middleware.Secure,
+		middleware.NoCache,
+		middleware.Version(replConfig.Version),
+	)
+
+	if replConfig.Compression {
+		chain = chain.Append(middleware.Gzip(gzip.DefaultCompression))
+	}
+
 	return &http.Server{
-		Addr: replConfig.ReplAddress,
-		Handler: alice.New(
-			chimiddleware.RealIP,
-			middleware.NewRateLimiter(1000, 1*time.Second, middleware.WithKeyFuncAll),
-			chimiddleware.RequestID,
-			middleware.Cors(corsConfig.CORS.AllowedOrigins, corsConfig.CORS.AllowedMethods, corsConfig.CORS.AllowedHeaders, corsConfig.CORS.AllowCredentials),
-			middleware.Secure,
-			middleware.NoCache,
-			middleware.Version(replConfig.Version),
-		).Then(mux),
+		Addr:    replConfig.ReplAddress,
+		Handler: chain.Then(mux),
 	}
 }
+
+// StartRepl registers http repl server lifecycle hooks. Called automatically
+// by fx and bootstrapper.
+//
+// Starts listening on start and gracefully shuts the server down on stop
+// letting in-flight requests finish within the timeout provided.
+func StartRepl(lc fx.Lifecycle, srv *http.Server, timeout time.Duration) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go srv.ListenAndServe()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			return srv.Shutdown(ctx)
+		},
+	})
+}
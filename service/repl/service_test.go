@@ -0,0 +1,95 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package repl
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.uber.org/fx/fxtest"
+)
+
+func TestStartReplGracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not reserve a port: %s", err.Error())
+	}
+
+	addr := listener.Addr().String()
+	listener.Close()
+
+	started := make(chan struct{})
+	srv := &http.Server{
+		Addr: addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+
+	lc := fxtest.NewLifecycle(t)
+	StartRepl(lc, srv, 2*time.Second)
+	lc.RequireStart()
+
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err != nil {
+		t.Fatalf("repl server did not start: %s", err.Error())
+	}
+
+	type result struct {
+		status int
+		err    error
+	}
+
+	results := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr)
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+
+		resp.Body.Close()
+		results <- result{status: resp.StatusCode}
+	}()
+
+	<-started
+	lc.RequireStop()
+
+	res := <-results
+	if res.err != nil {
+		t.Fatalf("expected the in-flight request to finish, got %s", res.err.Error())
+	}
+
+	if res.status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.status)
+	}
+}
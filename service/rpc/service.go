@@ -85,9 +85,7 @@ func NewService(
 		log.Fatalf("could not initialize a new broker instance: %s", err.Error())
 	}
 
-	if err := broker.Broker.Connect(); err != nil {
-		log.Fatalf("broker connection error: %s", err.Error())
-	}
+	// The broker is connected and disconnected by the bootstrapper lifecycle.
 
 	if resilienceConfig.Resilience.RateLimiter.Limit > 0 {
 		wrappers = append(wrappers, rlimiter.NewHandlerWrapper(int(resilienceConfig.Resilience.RateLimiter.Limit), uber.Per(1*time.Second)))
@@ -113,7 +111,6 @@ func NewService(
 		micro.Client(client),
 		micro.WrapClient(
 			roundrobin.NewClientWrapper(),
-			hystrix.NewClientWrapper(),
 			opentelemetry.NewClientWrapper(opentelemetry.WithTraceProvider(otel.GetTracerProvider())),
 		),
 		micro.WrapSubscriber(opentelemetry.NewSubscriberWrapper(opentelemetry.WithTraceProvider(otel.GetTracerProvider()))),
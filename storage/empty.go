@@ -28,6 +28,7 @@ import (
 	"context"
 
 	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type emptyStore struct {
@@ -53,11 +54,21 @@ func (s *emptyStore) Read(ctx context.Context, opts ...ReadOption) error {
 	return nil
 }
 
+// Check whether a key exists.
+func (s *emptyStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	return false, nil
+}
+
 // Write records.
 func (s *emptyStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
 	return nil
 }
 
+// Write a batch of records.
+func (s *emptyStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
+	return nil
+}
+
 // Update records
 func (s *emptyStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
 	return nil
@@ -68,6 +79,21 @@ func (s *emptyStore) Delete(ctx context.Context, opts ...DeleteOption) error {
 	return nil
 }
 
+// Run fn with the empty store.
+func (s *emptyStore) WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error {
+	return fn(s)
+}
+
+// Indexes are not supported by the empty store.
+func (s *emptyStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	return nil
+}
+
+// Checks database connectivity.
+func (s *emptyStore) Ping(ctx context.Context) error {
+	return nil
+}
+
 // Returns db options.
 func (s *emptyStore) Options() store.Options {
 	return store.Options{}
@@ -24,9 +24,36 @@
 // yaml configuration.
 package storage
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by every store when a record is missing. The
+// backend's original error is preserved, so errors.Is matches both.
+var ErrNotFound = errors.New("record not found")
+
+// ErrNoStorageNodes is returned when a storage is initialized without
+// any nodes (storage.url yaml or STORAGE_URL env parameter).
+var ErrNoStorageNodes = errors.New("no storage nodes configured. Expected a valid storage url (storage.url/STORAGE_URL)")
+
+// ErrTransactionsNotSupported is returned when a transaction is requested
+// from a store without transactions support.
+var ErrTransactionsNotSupported = errors.New("storage does not support transactions")
 
 var (
 	_errInvalidResultOption     = errors.New("expected to get a non-nil result option")
 	_errInvalidWritePayloadType = errors.New("unsupported write payload type")
+	_errInvalidWriteConcern     = errors.New("unsupported write concern. Expected majority or w<N>")
+	_errMissingWriteKey         = errors.New("expected a record key or a key write option")
 )
+
+// notFound translates a backend's missing record error into ErrNotFound.
+// Other errors are returned as is.
+func notFound(err, backend error) error {
+	if err != nil && errors.Is(err, backend) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+
+	return err
+}
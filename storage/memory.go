@@ -26,14 +26,25 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-micro/plugins/v4/store/memory"
 	"github.com/mitchellh/mapstructure"
 	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type memoryStore struct {
 	store store.Store
+	// mu serializes transactions.
+	mu sync.Mutex
 }
 
 // A RefinedStore go-micro in-memory constructor. Called automatically by fx and
@@ -49,13 +60,18 @@ func (s *memoryStore) Init(opts ...store.Option) error {
 	return s.store.Init(opts...)
 }
 
-// List all the keys
+// List all the keys. Keys of records matching the filter are ordered by the
+// sort field when either is set
 func (s *memoryStore) List(ctx context.Context, opts ...ReadOption) error {
 	var ops ReadOptions
 	for _, o := range opts {
 		o(&ops)
 	}
 
+	if len(ops.Filter) > 0 || ops.SortField != "" {
+		return s.listRecords(ops)
+	}
+
 	res, err := s.store.List(
 		store.ListFrom(ops.Database, ops.Table),
 		store.ListLimit(ops.Limit),
@@ -71,6 +87,67 @@ func (s *memoryStore) List(ctx context.Context, opts ...ReadOption) error {
 	return mapstructure.Decode(res, ops.Result)
 }
 
+// listRecords lists keys of the records matching the filter. go-micro's
+// memory store lists keys only, so records are read by prefix and matched
+// against their metadata and json encoded values. Pagination is applied
+// after filtering and sorting.
+//
+// The memory store returns no records for prefix reads without a limit,
+// hence the explicit one.
+func (s *memoryStore) listRecords(ops ReadOptions) error {
+	res, err := s.store.Read(
+		ops.Prefix,
+		store.ReadFrom(ops.Database, ops.Table),
+		store.ReadPrefix(),
+		store.ReadLimit(math.MaxInt32),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	records := make([]memoryRecord, 0, len(res))
+	for _, r := range res {
+		if ops.Suffix != "" && !strings.HasSuffix(r.Key, ops.Suffix) {
+			continue
+		}
+
+		record := newMemoryRecord(r)
+		if matchFilter(record.fields, ops.Filter) {
+			records = append(records, record)
+		}
+	}
+
+	if ops.SortField != "" {
+		sort.SliceStable(records, func(i, j int) bool {
+			a := fieldValue(records[i].fields, ops.SortField)
+			b := fieldValue(records[j].fields, ops.SortField)
+			if ops.SortAsc {
+				return lessValue(a, b)
+			}
+
+			return lessValue(b, a)
+		})
+	}
+
+	if int(ops.Offset) >= len(records) {
+		records = nil
+	} else {
+		records = records[ops.Offset:]
+	}
+
+	if ops.Limit > 0 && int(ops.Limit) < len(records) {
+		records = records[:ops.Limit]
+	}
+
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, record.record.Key)
+	}
+
+	return mapstructure.Decode(keys, ops.Result)
+}
+
 // Read a single record
 func (s *memoryStore) Read(ctx context.Context, opts ...ReadOption) error {
 	var ops ReadOptions
@@ -86,12 +163,51 @@ func (s *memoryStore) Read(ctx context.Context, opts ...ReadOption) error {
 	)
 
 	if err != nil {
-		return err
+		return notFound(err, store.ErrNotFound)
+	}
+
+	if len(ops.Filter) > 0 {
+		matched := make([]*store.Record, 0, len(res))
+		for _, r := range res {
+			if matchFilter(newMemoryRecord(r).fields, ops.Filter) {
+				matched = append(matched, r)
+			}
+		}
+
+		if len(matched) == 0 {
+			return notFound(store.ErrNotFound, store.ErrNotFound)
+		}
+
+		res = matched
 	}
 
 	return mapstructure.Decode(res, ops.Result)
 }
 
+// Check whether a record with a key exists
+func (s *memoryStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	keys, err := s.store.List(
+		store.ListFrom(ops.Database, ops.Table),
+		store.ListPrefix(ops.Key),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	for _, key := range keys {
+		if key == ops.Key {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // Write a record
 func (s *memoryStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
 	var ops WriteOptions
@@ -111,9 +227,82 @@ func (s *memoryStore) Write(ctx context.Context, payload any, opts ...WriteOptio
 	)
 }
 
-// Write duplicate
+// Write a batch of records
+func (s *memoryStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	for _, payload := range payloads {
+		p, ok := payload.(*store.Record)
+		if !ok {
+			return _errInvalidWritePayloadType
+		}
+
+		if err := s.store.Write(
+			p, store.WriteTo(ops.Database, ops.Table),
+			store.WriteExpiry(ops.Expiry),
+			store.WriteTTL(ops.TTL),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update a record found by key. Merges the payload into the existing
+// record and rewrites it under the same key
 func (s *memoryStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
-	return s.Write(ctx, payload, opts...)
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	p, ok := payload.(*store.Record)
+	if !ok {
+		return _errInvalidWritePayloadType
+	}
+
+	key := ops.Key
+	if key == "" {
+		key = p.Key
+	}
+
+	res, err := s.store.Read(key, store.ReadFrom(ops.Database, ops.Table))
+	if err != nil {
+		return notFound(err, store.ErrNotFound)
+	}
+
+	if len(res) == 0 {
+		return notFound(store.ErrNotFound, store.ErrNotFound)
+	}
+
+	record := res[0]
+	if p.Value != nil {
+		record.Value = p.Value
+	}
+
+	if len(p.Metadata) > 0 {
+		if record.Metadata == nil {
+			record.Metadata = make(map[string]interface{}, len(p.Metadata))
+		}
+
+		for k, v := range p.Metadata {
+			record.Metadata[k] = v
+		}
+	}
+
+	if p.Expiry > 0 {
+		record.Expiry = p.Expiry
+	}
+
+	return s.store.Write(
+		record, store.WriteTo(ops.Database, ops.Table),
+		store.WriteExpiry(ops.Expiry),
+		store.WriteTTL(ops.TTL),
+	)
 }
 
 // Delete a record with a key
@@ -129,6 +318,23 @@ func (s *memoryStore) Delete(ctx context.Context, opts ...DeleteOption) error {
 	)
 }
 
+// Run fn under a mutex. Changes are not rolled back on failure
+func (s *memoryStore) WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s)
+}
+
+// Indexes are not supported by the in-memory store
+func (s *memoryStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	return nil
+}
+
+// Checks database connectivity
+func (s *memoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
 // Returns db options
 func (s *memoryStore) Options() store.Options {
 	return s.store.Options()
@@ -138,3 +344,128 @@ func (s *memoryStore) Options() store.Options {
 func (s *memoryStore) String() string {
 	return s.store.String()
 }
+
+// memoryRecord is a memory store record along with its fields used
+// for filtering and sorting.
+type memoryRecord struct {
+	record *store.Record
+	fields reflect.Value
+}
+
+// newMemoryRecord collects record metadata and top-level fields of a json
+// encoded record value. Value fields take precedence.
+func newMemoryRecord(r *store.Record) memoryRecord {
+	fields := make(map[string]any, len(r.Metadata))
+	for k, v := range r.Metadata {
+		fields[k] = v
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(r.Value, &value); err == nil {
+		for k, v := range value {
+			fields[k] = v
+		}
+	}
+
+	return memoryRecord{record: r, fields: reflect.ValueOf(fields)}
+}
+
+// filterResult drops decoded slice elements (result is expected to be a pointer
+// to a slice) not matching every filter field.
+func filterResult(result any, filter map[string]any) {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr {
+		return
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Slice {
+		return
+	}
+
+	filtered := reflect.MakeSlice(rv.Type(), 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if matchFilter(rv.Index(i), filter) {
+			filtered = reflect.Append(filtered, rv.Index(i))
+		}
+	}
+
+	rv.Set(filtered)
+}
+
+// matchFilter reports whether every filter field equals the element's field.
+func matchFilter(v reflect.Value, filter map[string]any) bool {
+	for field, expected := range filter {
+		a, b := fieldValue(v, field), reflect.ValueOf(expected)
+		if !indirectValue(a).IsValid() || lessValue(a, b) || lessValue(b, a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldValue extracts a field or a map value by name.
+func fieldValue(v reflect.Value, field string) reflect.Value {
+	v = indirectValue(v)
+	switch v.Kind() {
+	case reflect.Struct:
+		return v.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, field)
+		})
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return v.MapIndex(reflect.ValueOf(field).Convert(v.Type().Key()))
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// lessValue compares two reflected values. Invalid values go first.
+func lessValue(a, b reflect.Value) bool {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return !a.IsValid() && b.IsValid()
+	}
+
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+
+	if at, ok := a.Interface().(time.Time); ok {
+		if bt, ok := b.Interface().(time.Time); ok {
+			return at.Before(bt)
+		}
+	}
+
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// indirectValue dereferences pointers and interfaces.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
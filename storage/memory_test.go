@@ -0,0 +1,185 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-micro.dev/v4/store"
+)
+
+type memoryTestDoc struct {
+	Tenant string `json:"tenant"`
+	Status string `json:"status"`
+	Size   int    `json:"size"`
+}
+
+func newTestMemoryStore(t *testing.T) RefinedStore {
+	s := NewMemoryStore()
+	for key, doc := range map[string]memoryTestDoc{
+		"doc:1": {Tenant: "a", Status: "active", Size: 30},
+		"doc:2": {Tenant: "a", Status: "deleted", Size: 10},
+		"doc:3": {Tenant: "b", Status: "active", Size: 20},
+		"doc:4": {Tenant: "a", Status: "active", Size: 5},
+		"tmp:5": {Tenant: "a", Status: "active", Size: 1},
+	} {
+		buf, _ := json.Marshal(doc)
+		if err := s.Write(context.Background(), &store.Record{Key: key, Value: buf}); err != nil {
+			t.Fatalf("could not write %s: %s", key, err.Error())
+		}
+	}
+
+	return s
+}
+
+func TestMemoryStoreListFilter(t *testing.T) {
+	s := newTestMemoryStore(t)
+
+	var keys []string
+	if err := s.List(
+		context.Background(),
+		ReadPrefix("doc:"),
+		ReadFilter(map[string]any{"tenant": "a", "status": "active"}),
+		ReadSort("size", true),
+		ReadResult(&keys),
+	); err != nil {
+		t.Fatalf("could not list records: %s", err.Error())
+	}
+
+	if expected := []string{"doc:4", "doc:1"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+}
+
+func TestMemoryStoreListSortPaginate(t *testing.T) {
+	s := newTestMemoryStore(t)
+
+	var keys []string
+	if err := s.List(
+		context.Background(),
+		ReadPrefix("doc:"),
+		ReadSort("size", false),
+		ReadOffset(1),
+		ReadLimit(2),
+		ReadResult(&keys),
+	); err != nil {
+		t.Fatalf("could not list records: %s", err.Error())
+	}
+
+	if expected := []string{"doc:3", "doc:2"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %v, got %v", expected, keys)
+	}
+}
+
+func TestMemoryStoreReadFilter(t *testing.T) {
+	s := newTestMemoryStore(t)
+
+	var records []*store.Record
+	if err := s.Read(
+		context.Background(),
+		ReadKey("doc:1"),
+		ReadFilter(map[string]any{"tenant": "a", "status": "active"}),
+		ReadResult(&records),
+	); err != nil || len(records) != 1 {
+		t.Fatalf("expected a matching record, got %d records and %v", len(records), err)
+	}
+
+	if err := s.Read(
+		context.Background(),
+		ReadKey("doc:2"),
+		ReadFilter(map[string]any{"tenant": "a", "status": "active"}),
+		ReadResult(&records),
+	); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a not found error for a non-matching record, got %v", err)
+	}
+}
+
+func TestMemoryStoreUpdate(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	if err := s.Write(ctx, &store.Record{
+		Key:      "doc",
+		Value:    []byte("stale"),
+		Metadata: map[string]interface{}{"owner": "a"},
+	}); err != nil {
+		t.Fatalf("could not write a record: %s", err.Error())
+	}
+
+	if err := s.Update(ctx, &store.Record{
+		Value:    []byte("fresh"),
+		Metadata: map[string]interface{}{"status": "active"},
+	}, WriteKey("doc")); err != nil {
+		t.Fatalf("could not update a record: %s", err.Error())
+	}
+
+	var keys []string
+	if err := s.List(ctx, ReadResult(&keys)); err != nil {
+		t.Fatalf("could not list records: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(keys, []string{"doc"}) {
+		t.Fatalf("expected a single record, got %v", keys)
+	}
+
+	var records []*store.Record
+	if err := s.Read(ctx, ReadKey("doc"), ReadResult(&records)); err != nil || len(records) != 1 {
+		t.Fatalf("could not read an updated record: %v", err)
+	}
+
+	if string(records[0].Value) != "fresh" {
+		t.Fatalf("expected the updated value, got %q", records[0].Value)
+	}
+
+	if records[0].Metadata["owner"] != "a" || records[0].Metadata["status"] != "active" {
+		t.Fatalf("expected metadata to be merged, got %v", records[0].Metadata)
+	}
+}
+
+func TestMemoryStoreUpdateMissing(t *testing.T) {
+	err := NewMemoryStore().Update(context.Background(), &store.Record{Value: []byte("value")}, WriteKey("missing"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestMemoryStoreExists(t *testing.T) {
+	s := newTestMemoryStore(t)
+	for key, exists := range map[string]bool{"doc:1": true, "doc:": false, "missing": false} {
+		found, err := s.Exists(context.Background(), ReadKey(key))
+		if err != nil {
+			t.Fatalf("could not check %s: %s", key, err.Error())
+		}
+
+		if found != exists {
+			t.Fatalf("expected %s existence to be %v, got %v", key, exists, found)
+		}
+	}
+}
+
+func TestMemoryStoreReadNotFound(t *testing.T) {
+	var records []*store.Record
+	err := NewMemoryStore().Read(context.Background(), ReadKey("missing"), ReadResult(&records))
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected a wrapped not found error, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package storage provides a store wrapper over go-micro's store.Store and
+// several implementations.
+//
+// The store package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	storageOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "storage_operations_total",
+		Help: "Total number of storage operations.",
+	}, []string{"store", "method", "status"})
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "storage_operation_duration_seconds",
+		Help:    "Storage operations latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"store", "method", "status"})
+	registerStorageMetrics sync.Once
+)
+
+type instrumentedStore struct {
+	inner RefinedStore
+}
+
+// NewInstrumentedStore wraps a RefinedStore to record per-method operation
+// counters and latency histograms labeled by store name and status.
+// Metrics are registered against the default prometheus registry.
+func NewInstrumentedStore(inner RefinedStore) RefinedStore {
+	registerStorageMetrics.Do(func() {
+		prometheus.MustRegister(storageOperations, storageDuration)
+	})
+
+	return &instrumentedStore{inner: inner}
+}
+
+func (s *instrumentedStore) observe(method string, start time.Time, err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+
+	storageOperations.WithLabelValues(s.inner.String(), method, status).Inc()
+	storageDuration.WithLabelValues(s.inner.String(), method, status).
+		Observe(time.Since(start).Seconds())
+}
+
+func (s *instrumentedStore) Init(opts ...store.Option) error {
+	return s.inner.Init(opts...)
+}
+
+func (s *instrumentedStore) List(ctx context.Context, opts ...ReadOption) error {
+	start := time.Now()
+	err := s.inner.List(ctx, opts...)
+	s.observe("List", start, err)
+	return err
+}
+
+func (s *instrumentedStore) Read(ctx context.Context, opts ...ReadOption) error {
+	start := time.Now()
+	err := s.inner.Read(ctx, opts...)
+	s.observe("Read", start, err)
+	return err
+}
+
+func (s *instrumentedStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	start := time.Now()
+	exists, err := s.inner.Exists(ctx, opts...)
+	s.observe("Exists", start, err)
+	return exists, err
+}
+
+func (s *instrumentedStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
+	start := time.Now()
+	err := s.inner.Write(ctx, payload, opts...)
+	s.observe("Write", start, err)
+	return err
+}
+
+func (s *instrumentedStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
+	start := time.Now()
+	err := s.inner.WriteMany(ctx, payloads, opts...)
+	s.observe("WriteMany", start, err)
+	return err
+}
+
+func (s *instrumentedStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
+	start := time.Now()
+	err := s.inner.Update(ctx, payload, opts...)
+	s.observe("Update", start, err)
+	return err
+}
+
+func (s *instrumentedStore) Delete(ctx context.Context, opts ...DeleteOption) error {
+	start := time.Now()
+	err := s.inner.Delete(ctx, opts...)
+	s.observe("Delete", start, err)
+	return err
+}
+
+func (s *instrumentedStore) WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error {
+	transactional, ok := s.inner.(TransactionalStore)
+	if !ok {
+		return ErrTransactionsNotSupported
+	}
+
+	return transactional.WithTransaction(ctx, func(tx RefinedStore) error {
+		return fn(&instrumentedStore{inner: tx})
+	})
+}
+
+func (s *instrumentedStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	if indexed, ok := s.inner.(IndexedStore); ok {
+		return indexed.EnsureIndexes(ctx, table, models)
+	}
+
+	return nil
+}
+
+func (s *instrumentedStore) Ping(ctx context.Context) error {
+	return s.inner.Ping(ctx)
+}
+
+func (s *instrumentedStore) Options() store.Options {
+	return s.inner.Options()
+}
+
+func (s *instrumentedStore) String() string {
+	return s.inner.String()
+}
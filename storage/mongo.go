@@ -28,15 +28,23 @@ import (
 	"context"
 	"time"
 
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"github.com/kamva/mgm/v3"
 	"go-micro.dev/v4/store"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
+// softDeleteField is a document field set on soft delete.
+const softDeleteField = "deleted_at"
+
 type mongoStore struct {
 	options store.Options
+	// session is set for stores bound to a transaction.
+	session mongo.SessionContext
 }
 
 // A RefinedStore mongo constructor. Called automatically by fx and
@@ -46,12 +54,69 @@ func NewMongoStore() RefinedStore {
 }
 
 func (s *mongoStore) configure() error {
+	if len(s.options.Nodes) == 0 || s.options.Nodes[0] == "" {
+		return ErrNoStorageNodes
+	}
+
+	copts, err := mongoClientOptions(s.options)
+	if err != nil {
+		return err
+	}
+
 	return mgm.SetDefaultConfig(
 		&mgm.Config{CtxTimeout: 3 * time.Second}, s.options.Database,
-		options.Client().ApplyURI(s.options.Nodes[0]),
+		copts,
 	)
 }
 
+// mongoClientOptions builds client options from the first node's uri
+// along with the read preference and write concern store options.
+func mongoClientOptions(opts store.Options) (*options.ClientOptions, error) {
+	copts := options.Client().ApplyURI(opts.Nodes[0])
+	if opts.Context == nil {
+		return copts, nil
+	}
+
+	if mode, ok := opts.Context.Value(readPreferenceKey{}).(string); ok && mode != "" {
+		rmode, err := readpref.ModeFromString(mode)
+		if err != nil {
+			return nil, err
+		}
+
+		pref, err := readpref.New(rmode)
+		if err != nil {
+			return nil, err
+		}
+
+		copts = copts.SetReadPreference(pref)
+	}
+
+	if concern, ok := opts.Context.Value(writeConcernKey{}).(string); ok && concern != "" {
+		wc, err := parseWriteConcern(concern)
+		if err != nil {
+			return nil, err
+		}
+
+		copts = copts.SetWriteConcern(wc)
+	}
+
+	return copts, nil
+}
+
+// parseWriteConcern converts majority or w<N> into a mongo write concern.
+func parseWriteConcern(concern string) (*writeconcern.WriteConcern, error) {
+	w, majority, err := config.ParseWriteConcern(concern)
+	if err != nil {
+		return nil, _errInvalidWriteConcern
+	}
+
+	if majority {
+		return writeconcern.Majority(), nil
+	}
+
+	return &writeconcern.WriteConcern{W: w}, nil
+}
+
 func (s *mongoStore) Init(opts ...store.Option) error {
 	for _, o := range opts {
 		o(&s.options)
@@ -67,11 +132,22 @@ func (s *mongoStore) List(ctx context.Context, opts ...ReadOption) error {
 		o(&ops)
 	}
 
+	fopts := options.Find().SetSkip(int64(ops.Offset)).SetLimit(int64(ops.Limit))
+	if ops.SortField != "" {
+		direction := -1
+		if ops.SortAsc {
+			direction = 1
+		}
+
+		fopts = fopts.SetSort(bson.D{{Key: ops.SortField, Value: direction}})
+	}
+
+	if projection := buildProjection(ops.Projection); projection != nil {
+		fopts = fopts.SetProjection(projection)
+	}
+
 	col := mgm.CollectionByName(ops.Table)
-	cur, err := col.Find(
-		ctx, bson.D{},
-		options.Find().SetSkip(int64(ops.Offset)).SetLimit(int64(ops.Limit)),
-	)
+	cur, err := col.Find(s.sessionContext(ctx), buildFilter(ops, false, s.softDeleted(ops.Table)), fopts)
 
 	if err != nil {
 		return err
@@ -90,27 +166,49 @@ func (s *mongoStore) List(ctx context.Context, opts ...ReadOption) error {
 
 // Read a single document.
 func (s *mongoStore) Read(ctx context.Context, opts ...ReadOption) error {
-	var options ReadOptions
+	var ops ReadOptions
 	for _, o := range opts {
-		o(&options)
+		o(&ops)
 	}
 
-	col := mgm.CollectionByName(options.Table)
-	sres := col.FindOne(ctx, bson.M{
-		options.Key: options.Value,
-	})
+	fopts := options.FindOne()
+	if projection := buildProjection(ops.Projection); projection != nil {
+		fopts = fopts.SetProjection(projection)
+	}
+
+	col := mgm.CollectionByName(ops.Table)
+	sres := col.FindOne(s.sessionContext(ctx), buildFilter(ops, true, s.softDeleted(ops.Table)), fopts)
 
-	if options.Result == nil {
+	if ops.Result == nil {
 		return _errInvalidResultOption
 	}
 
-	if err := sres.Decode(options.Result); err != nil {
-		return err
+	if err := sres.Decode(ops.Result); err != nil {
+		return notFound(err, mongo.ErrNoDocuments)
 	}
 
 	return nil
 }
 
+// Check whether a document matching the filter exists.
+func (s *mongoStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	col := mgm.CollectionByName(ops.Table)
+	count, err := col.CountDocuments(
+		s.sessionContext(ctx), buildFilter(ops, true, s.softDeleted(ops.Table)),
+		options.Count().SetLimit(1),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 // Write a document.
 func (s *mongoStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
 	var options WriteOptions
@@ -118,36 +216,51 @@ func (s *mongoStore) Write(ctx context.Context, payload any, opts ...WriteOption
 		o(&options)
 	}
 
-	return mgm.TransactionWithCtx(ctx, func(session mongo.Session, sc mongo.SessionContext) error {
+	return s.withSession(ctx, func(sc mongo.SessionContext) error {
 		col := mgm.CollectionByName(options.Table)
-		if _, err := col.InsertOne(ctx, payload); err != nil {
-			return err
-		}
-
-		return session.CommitTransaction(sc)
+		_, err := col.InsertOne(sc, payload)
+		return err
 	})
 }
 
-// Update a document
-func (s *mongoStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
+// Write a batch of documents within a single transaction.
+// TTL and Expiry are not supported and expected to be handled via TTL indexes.
+func (s *mongoStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
 	var options WriteOptions
 	for _, o := range opts {
 		o(&options)
 	}
 
-	return mgm.TransactionWithCtx(ctx, func(session mongo.Session, sc mongo.SessionContext) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	return s.withSession(ctx, func(sc mongo.SessionContext) error {
 		col := mgm.CollectionByName(options.Table)
-		filter := bson.D{{Key: options.Key, Value: options.Value}}
-		update := bson.D{{Key: "$set", Value: payload}}
-		if _, err := col.UpdateOne(ctx, filter, update); err != nil {
-			return err
-		}
+		_, err := col.InsertMany(sc, payloads)
+		return err
+	})
+}
 
-		return session.CommitTransaction(sc)
+// Update a document. Inserts a new one if upsert is enabled
+// and no document matches the filter.
+func (s *mongoStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	return s.withSession(ctx, func(sc mongo.SessionContext) error {
+		col := mgm.CollectionByName(ops.Table)
+		filter := bson.D{{Key: ops.Key, Value: ops.Value}}
+		update := bson.D{{Key: "$set", Value: payload}}
+		_, err := col.UpdateOne(sc, filter, update, options.Update().SetUpsert(ops.Upsert))
+		return err
 	})
 }
 
-// Delete a document with key
+// Delete a document with key. Soft delete sets the document's
+// deleted_at field instead of removing it.
 func (s *mongoStore) Delete(ctx context.Context, opts ...DeleteOption) error {
 	var options DeleteOptions
 	for _, o := range opts {
@@ -156,11 +269,42 @@ func (s *mongoStore) Delete(ctx context.Context, opts ...DeleteOption) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	return mgm.TransactionWithCtx(ctx, func(session mongo.Session, sc mongo.SessionContext) error {
+	return s.withSession(ctx, func(sc mongo.SessionContext) error {
 		col := mgm.CollectionByName(options.Table)
-		if _, err := col.DeleteOne(ctx, bson.M{
-			options.Key: options.Value,
-		}); err != nil {
+		filter := bson.M{options.Key: options.Value}
+		if options.Soft {
+			_, err := col.UpdateOne(sc, filter, bson.M{
+				"$set": bson.M{softDeleteField: time.Now()},
+			})
+			return err
+		}
+
+		_, err := col.DeleteOne(sc, filter)
+		return err
+	})
+}
+
+// Run fn within a single transaction. Operations on tx share
+// the transaction's session and are committed or aborted together.
+func (s *mongoStore) WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error {
+	if s.session != nil {
+		return fn(s)
+	}
+
+	return s.withSession(ctx, func(sc mongo.SessionContext) error {
+		return fn(&mongoStore{options: s.options, session: sc})
+	})
+}
+
+// withSession runs fn within the bound transaction's session or
+// starts and commits a new transaction otherwise.
+func (s *mongoStore) withSession(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	if s.session != nil {
+		return fn(s.session)
+	}
+
+	return mgm.TransactionWithCtx(ctx, func(session mongo.Session, sc mongo.SessionContext) error {
+		if err := fn(sc); err != nil {
 			return err
 		}
 
@@ -168,6 +312,92 @@ func (s *mongoStore) Delete(ctx context.Context, opts ...DeleteOption) error {
 	})
 }
 
+// sessionContext returns the bound transaction's session context
+// so that reads observe uncommitted transaction writes.
+func (s *mongoStore) sessionContext(ctx context.Context) context.Context {
+	if s.session != nil {
+		return s.session
+	}
+
+	return ctx
+}
+
+// Creates indexes on a collection. Existing indexes with the same
+// specification are left intact.
+func (s *mongoStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	_, err := mgm.CollectionByName(table).Indexes().CreateMany(ctx, models)
+	return err
+}
+
+// Checks database connectivity.
+func (s *mongoStore) Ping(ctx context.Context) error {
+	_, client, _, err := mgm.DefaultConfigs()
+	if err != nil {
+		return err
+	}
+
+	return client.Ping(ctx, readpref.Primary())
+}
+
+// softDeleted checks whether soft delete is enabled for a table.
+func (s *mongoStore) softDeleted(table string) bool {
+	if s.options.Context == nil {
+		return false
+	}
+
+	tables, _ := s.options.Context.Value(softDeleteKey{}).([]string)
+	for _, t := range tables {
+		if t == "*" || t == table {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildFilter converts read options into a mongo filter. Key/Values are
+// merged into the Filter map as $in. The Key/Value pair is merged only
+// when matchValue is set, since lists ignore it. Soft-deleted documents
+// are excluded when softDelete is set unless IncludeDeleted is set.
+func buildFilter(ops ReadOptions, matchValue, softDelete bool) bson.M {
+	filter := make(bson.M, len(ops.Filter)+2)
+	for k, v := range ops.Filter {
+		filter[k] = v
+	}
+
+	switch {
+	case ops.Key != "" && len(ops.Values) > 0:
+		filter[ops.Key] = bson.M{"$in": ops.Values}
+	case ops.Key != "" && matchValue:
+		filter[ops.Key] = ops.Value
+	}
+
+	if _, ok := filter[softDeleteField]; softDelete && !ok && !ops.IncludeDeleted {
+		filter[softDeleteField] = bson.M{"$exists": false}
+	}
+
+	return filter
+}
+
+// buildProjection converts a list of fields into a mongo projection.
+// Returns nil if no fields are provided.
+func buildProjection(fields []string) bson.M {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projection := make(bson.M, len(fields))
+	for _, field := range fields {
+		projection[field] = 1
+	}
+
+	return projection
+}
+
 // Returns db options.
 func (s *mongoStore) Options() store.Options {
 	return s.options
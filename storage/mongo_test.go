@@ -0,0 +1,364 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kamva/mgm/v3"
+	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type mongoTestDoc struct {
+	ID     string `bson:"_id"`
+	Tenant string `bson:"tenant"`
+	Status string `bson:"status"`
+}
+
+// newTestMongoStore connects to a MongoDB replica set provided via
+// STORAGE_TEST_MONGO_URL. Writes run within transactions, so a standalone
+// server is not enough. Returns the store along with a collection name
+// dropped after the test.
+func newTestMongoStore(t *testing.T, opts ...store.Option) (RefinedStore, string) {
+	url := os.Getenv("STORAGE_TEST_MONGO_URL")
+	if url == "" {
+		t.Skip("STORAGE_TEST_MONGO_URL is not set")
+	}
+
+	s := NewMongoStore()
+	if err := s.Init(append([]store.Option{
+		store.Database("adapters_test"),
+		store.Nodes(url),
+	}, opts...)...); err != nil {
+		t.Fatalf("could not initialize a mongo store: %s", err.Error())
+	}
+
+	table := strings.ReplaceAll(strings.ToLower(t.Name()), "/", "_")
+	t.Cleanup(func() {
+		mgm.CollectionByName(table).Drop(context.Background())
+	})
+
+	return s, table
+}
+
+func writeTestMongoDocs(t *testing.T, s RefinedStore, table string, docs ...mongoTestDoc) {
+	for _, doc := range docs {
+		if err := s.Write(context.Background(), doc, WriteTo("", table)); err != nil {
+			t.Fatalf("could not write %s: %s", doc.ID, err.Error())
+		}
+	}
+}
+
+func TestBuildFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		ops        ReadOptions
+		matchValue bool
+		softDelete bool
+		expected   bson.M
+	}{
+		{
+			name:     "list ignores key and value",
+			ops:      ReadOptions{Key: "tenant", Value: "a"},
+			expected: bson.M{},
+		},
+		{
+			name:       "read matches key and value",
+			ops:        ReadOptions{Key: "tenant", Value: "a"},
+			matchValue: true,
+			expected:   bson.M{"tenant": "a"},
+		},
+		{
+			name:     "list matches key values",
+			ops:      ReadOptions{Key: "_id", Values: []string{"1", "2"}},
+			expected: bson.M{"_id": bson.M{"$in": []string{"1", "2"}}},
+		},
+		{
+			name:       "filter is merged with key and value",
+			ops:        ReadOptions{Key: "tenant", Value: "a", Filter: map[string]any{"status": "active"}},
+			matchValue: true,
+			expected:   bson.M{"tenant": "a", "status": "active"},
+		},
+		{
+			name:       "soft deleted documents are excluded",
+			ops:        ReadOptions{Key: "tenant", Value: "a"},
+			matchValue: true,
+			softDelete: true,
+			expected:   bson.M{"tenant": "a", softDeleteField: bson.M{"$exists": false}},
+		},
+		{
+			name:       "soft deleted documents are included on demand",
+			ops:        ReadOptions{Key: "tenant", Value: "a", IncludeDeleted: true},
+			matchValue: true,
+			softDelete: true,
+			expected:   bson.M{"tenant": "a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if filter := buildFilter(tt.ops, tt.matchValue, tt.softDelete); !reflect.DeepEqual(filter, tt.expected) {
+				t.Fatalf("expected %v filter, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+func TestMongoClientOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []store.Option
+		mode    readpref.Mode
+		concern any
+	}{
+		{name: "defaults"},
+		{name: "primary", opts: []store.Option{WithReadPreference("primary")}, mode: readpref.PrimaryMode},
+		{name: "secondary", opts: []store.Option{WithReadPreference("secondary")}, mode: readpref.SecondaryMode},
+		{name: "majority", opts: []store.Option{WithWriteConcern("majority")}, concern: "majority"},
+		{name: "w1", opts: []store.Option{WithWriteConcern("w1")}, concern: 1},
+		{
+			name:    "secondary majority",
+			opts:    []store.Option{WithReadPreference("secondary"), WithWriteConcern("majority")},
+			mode:    readpref.SecondaryMode,
+			concern: "majority",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sopts := store.Options{Nodes: []string{"mongodb://localhost:27017"}}
+			for _, o := range tt.opts {
+				o(&sopts)
+			}
+
+			copts, err := mongoClientOptions(sopts)
+			if err != nil {
+				t.Fatalf("could not build client options: %s", err.Error())
+			}
+
+			switch {
+			case tt.mode == 0 && copts.ReadPreference != nil:
+				t.Fatalf("expected no read preference, got %v", copts.ReadPreference)
+			case tt.mode != 0 && (copts.ReadPreference == nil || copts.ReadPreference.Mode() != tt.mode):
+				t.Fatalf("expected %v read preference, got %v", tt.mode, copts.ReadPreference)
+			}
+
+			switch {
+			case tt.concern == nil && copts.WriteConcern != nil:
+				t.Fatalf("expected no write concern, got %v", copts.WriteConcern)
+			case tt.concern != nil && (copts.WriteConcern == nil || copts.WriteConcern.W != tt.concern):
+				t.Fatalf("expected %v write concern, got %v", tt.concern, copts.WriteConcern)
+			}
+		})
+	}
+
+	sopts := store.Options{Nodes: []string{"mongodb://localhost:27017"}}
+	WithWriteConcern("all")(&sopts)
+	if _, err := mongoClientOptions(sopts); !errors.Is(err, _errInvalidWriteConcern) {
+		t.Fatalf("expected an invalid write concern error, got %v", err)
+	}
+}
+
+func TestMongoStoreSoftDeleted(t *testing.T) {
+	s := &mongoStore{}
+	if s.softDeleted("docs") {
+		t.Fatal("expected soft delete to be disabled by default")
+	}
+
+	WithSoftDelete("docs")(&s.options)
+	if !s.softDeleted("docs") || s.softDeleted("tokens") {
+		t.Fatal("expected soft delete to be enabled for the docs table only")
+	}
+
+	WithSoftDelete()(&s.options)
+	if !s.softDeleted("tokens") {
+		t.Fatal("expected soft delete to be enabled for every table")
+	}
+}
+
+func TestMongoStoreSoftDelete(t *testing.T) {
+	s, table := newTestMongoStore(t, WithSoftDelete())
+	ctx := context.Background()
+	writeTestMongoDocs(t, s, table,
+		mongoTestDoc{ID: "1", Tenant: "a", Status: "active"},
+		mongoTestDoc{ID: "2", Tenant: "a", Status: "active"},
+	)
+
+	if err := s.Delete(ctx, DeleteFrom("", table), DeleteKey("_id"), DeleteValue("1"), DeleteSoft(true)); err != nil {
+		t.Fatalf("could not soft delete a document: %s", err.Error())
+	}
+
+	var docs []mongoTestDoc
+	if err := s.List(ctx, ReadFrom("", table), ReadResult(&docs)); err != nil {
+		t.Fatalf("could not list documents: %s", err.Error())
+	}
+
+	if len(docs) != 1 || docs[0].ID != "2" {
+		t.Fatalf("expected a soft-deleted document to be hidden, got %v", docs)
+	}
+
+	var doc mongoTestDoc
+	if err := s.Read(ctx, ReadFrom("", table), ReadKey("_id"), ReadValue("1"), ReadResult(&doc)); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a soft-deleted document not to be found, got %v", err)
+	}
+
+	if err := s.Read(
+		ctx, ReadFrom("", table), ReadKey("_id"), ReadValue("1"),
+		ReadIncludeDeleted(true), ReadResult(&doc),
+	); err != nil || doc.ID != "1" {
+		t.Fatalf("expected a soft-deleted document to be recoverable, got %v", err)
+	}
+}
+
+func TestMongoStoreListValues(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	writeTestMongoDocs(t, s, table,
+		mongoTestDoc{ID: "1", Tenant: "a", Status: "active"},
+		mongoTestDoc{ID: "2", Tenant: "a", Status: "active"},
+		mongoTestDoc{ID: "3", Tenant: "b", Status: "active"},
+	)
+
+	var docs []mongoTestDoc
+	if err := s.List(
+		context.Background(), ReadFrom("", table),
+		ReadKey("_id"), ReadValues("1", "3", "4"),
+		ReadSort("_id", true), ReadResult(&docs),
+	); err != nil {
+		t.Fatalf("could not list documents: %s", err.Error())
+	}
+
+	if len(docs) != 2 || docs[0].ID != "1" || docs[1].ID != "3" {
+		t.Fatalf("expected only matching documents, got %v", docs)
+	}
+}
+
+func TestMongoStoreUpsert(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	ctx := context.Background()
+	if err := s.Update(
+		ctx, bson.M{"tenant": "a", "status": "active"},
+		WriteTo("", table), WriteKey("_id"), WriteValue("1"), WriteUpsert(true),
+	); err != nil {
+		t.Fatalf("could not upsert a document: %s", err.Error())
+	}
+
+	var doc mongoTestDoc
+	if err := s.Read(ctx, ReadFrom("", table), ReadKey("_id"), ReadValue("1"), ReadResult(&doc)); err != nil {
+		t.Fatalf("could not read an upserted document: %s", err.Error())
+	}
+
+	if doc.Tenant != "a" || doc.Status != "active" {
+		t.Fatalf("expected the upserted document, got %+v", doc)
+	}
+}
+
+func TestMongoStoreEnsureIndexes(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	indexed, ok := s.(IndexedStore)
+	if !ok {
+		t.Fatal("expected the mongo store to support indexes")
+	}
+
+	models := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "tenant", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}}
+
+	for i := 0; i < 2; i++ {
+		if err := indexed.EnsureIndexes(context.Background(), table, models); err != nil {
+			t.Fatalf("could not ensure indexes: %s", err.Error())
+		}
+	}
+
+	writeTestMongoDocs(t, s, table, mongoTestDoc{ID: "1", Tenant: "a", Status: "active"})
+	if err := s.Write(
+		context.Background(), mongoTestDoc{ID: "2", Tenant: "a", Status: "active"}, WriteTo("", table),
+	); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("expected a duplicate key error, got %v", err)
+	}
+}
+
+func TestMongoStoreTransactionRollback(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	transactional, ok := s.(TransactionalStore)
+	if !ok {
+		t.Fatal("expected the mongo store to support transactions")
+	}
+
+	ctx := context.Background()
+	writeTestMongoDocs(t, s, table, mongoTestDoc{ID: "nonce", Tenant: "a", Status: "active"})
+
+	errAbort := errors.New("abort")
+	if err := transactional.WithTransaction(ctx, func(tx RefinedStore) error {
+		if err := tx.Write(ctx, mongoTestDoc{ID: "token", Tenant: "a", Status: "active"}, WriteTo("", table)); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(ctx, DeleteFrom("", table), DeleteKey("_id"), DeleteValue("nonce")); err != nil {
+			return err
+		}
+
+		return errAbort
+	}); !errors.Is(err, errAbort) {
+		t.Fatalf("expected the transaction error, got %v", err)
+	}
+
+	var doc mongoTestDoc
+	if err := s.Read(ctx, ReadFrom("", table), ReadKey("_id"), ReadValue("token"), ReadResult(&doc)); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the write to be rolled back, got %v", err)
+	}
+
+	if err := s.Read(ctx, ReadFrom("", table), ReadKey("_id"), ReadValue("nonce"), ReadResult(&doc)); err != nil {
+		t.Fatalf("expected the delete to be rolled back, got %v", err)
+	}
+}
+
+func TestMongoStoreExists(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	writeTestMongoDocs(t, s, table, mongoTestDoc{ID: "1", Tenant: "a", Status: "active"})
+
+	for id, exists := range map[string]bool{"1": true, "2": false} {
+		found, err := s.Exists(context.Background(), ReadFrom("", table), ReadKey("_id"), ReadValue(id))
+		if err != nil {
+			t.Fatalf("could not check %s: %s", id, err.Error())
+		}
+
+		if found != exists {
+			t.Fatalf("expected %s existence to be %v, got %v", id, exists, found)
+		}
+	}
+}
+
+func TestMongoStoreReadNotFound(t *testing.T) {
+	s, table := newTestMongoStore(t)
+	var doc mongoTestDoc
+	err := s.Read(context.Background(), ReadFrom("", table), ReadKey("_id"), ReadValue("missing"), ReadResult(&doc))
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected a wrapped not found error, got %v", err)
+	}
+}
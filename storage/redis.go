@@ -0,0 +1,418 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package storage provides a store wrapper over go-micro's store.Store and
+// several implementations.
+//
+// The store package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type redisStore struct {
+	options store.Options
+	client  *redis.Client
+}
+
+// A RefinedStore redis constructor. Called automatically by fx and
+// bootstrapper.
+//
+// Payloads are serialized with JSON and stored under table namespaced keys
+// (table:key:value).
+func NewRedisStore() RefinedStore {
+	return &redisStore{}
+}
+
+func (s *redisStore) configure() error {
+	if len(s.options.Nodes) == 0 || s.options.Nodes[0] == "" {
+		return ErrNoStorageNodes
+	}
+
+	opts := &redis.Options{Addr: s.options.Nodes[0]}
+	if strings.Contains(s.options.Nodes[0], "://") {
+		var err error
+		if opts, err = redis.ParseURL(s.options.Nodes[0]); err != nil {
+			return err
+		}
+	}
+
+	if s.options.Database != "" {
+		db, err := strconv.Atoi(s.options.Database)
+		if err != nil {
+			return err
+		}
+
+		opts.DB = db
+	}
+
+	s.client = redis.NewClient(opts)
+	return nil
+}
+
+func (s *redisStore) Init(opts ...store.Option) error {
+	for _, o := range opts {
+		o(&s.options)
+	}
+
+	return s.configure()
+}
+
+// List all the known records matching prefix and suffix.
+// Records are decoded and filtered before pagination when a filter is set.
+func (s *redisStore) List(ctx context.Context, opts ...ReadOption) error {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	if ops.Result == nil {
+		return _errInvalidResultOption
+	}
+
+	var keys []string
+	pattern := redisEscapePattern(ops.Table+":"+ops.Prefix) + "*" + redisEscapePattern(ops.Suffix)
+	iter := s.client.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	sort.Strings(keys)
+	if len(ops.Filter) == 0 {
+		lo, hi := paginationBounds(len(keys), ops.Offset, ops.Limit)
+		keys = keys[lo:hi]
+	}
+
+	values := make([]json.RawMessage, 0, len(keys))
+	if len(keys) > 0 {
+		res, err := s.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+
+		for _, val := range res {
+			if str, ok := val.(string); ok {
+				values = append(values, json.RawMessage(str))
+			}
+		}
+	}
+
+	buf, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(buf, ops.Result); err != nil {
+		return err
+	}
+
+	if len(ops.Filter) > 0 {
+		filterResult(ops.Result, ops.Filter)
+		paginateResult(ops.Result, ops.Offset, ops.Limit)
+	}
+
+	return nil
+}
+
+// Read a single record.
+func (s *redisStore) Read(ctx context.Context, opts ...ReadOption) error {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	if ops.Result == nil {
+		return _errInvalidResultOption
+	}
+
+	buf, err := s.client.Get(ctx, redisKey(ops.Table, ops.Key, ops.Value)).Bytes()
+	if err != nil {
+		return notFound(err, redis.Nil)
+	}
+
+	return json.Unmarshal(buf, ops.Result)
+}
+
+// Check whether a record exists.
+func (s *redisStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	count, err := s.client.Exists(ctx, redisKey(ops.Table, ops.Key, ops.Value)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Write a record.
+func (s *redisStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	key, err := redisPayloadKey(ops, payload)
+	if err != nil {
+		return err
+	}
+
+	expiration, expired := redisExpiration(ops)
+	if expired {
+		return s.client.Del(ctx, key).Err()
+	}
+
+	return s.client.Set(ctx, key, buf, expiration).Err()
+}
+
+// Write a batch of records within a single transaction.
+// Every payload is expected to be a *store.Record with a key.
+func (s *redisStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	expiration, expired := redisExpiration(ops)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, payload := range payloads {
+			if _, ok := payload.(*store.Record); !ok {
+				return _errInvalidWritePayloadType
+			}
+
+			key, err := redisPayloadKey(ops, payload)
+			if err != nil {
+				return err
+			}
+
+			buf, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+
+			if expired {
+				pipe.Del(ctx, key)
+				continue
+			}
+
+			pipe.Set(ctx, key, buf, expiration)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// Update a record. Merges the payload into the existing record
+// and inserts a new one if upsert is enabled.
+func (s *redisStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	key, err := redisPayloadKey(ops, payload)
+	if err != nil {
+		return err
+	}
+
+	record := make(map[string]any)
+	buf, err := s.client.Get(ctx, key).Bytes()
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(buf, &record); err != nil {
+			return err
+		}
+	case err == redis.Nil && ops.Upsert:
+	default:
+		return notFound(err, redis.Nil)
+	}
+
+	if buf, err = json.Marshal(payload); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(buf, &record); err != nil {
+		return err
+	}
+
+	if buf, err = json.Marshal(record); err != nil {
+		return err
+	}
+
+	expiration, expired := redisExpiration(ops)
+	if expired {
+		return s.client.Del(ctx, key).Err()
+	}
+
+	if expiration == 0 {
+		expiration = redis.KeepTTL
+	}
+
+	return s.client.Set(ctx, key, buf, expiration).Err()
+}
+
+// Delete a record with key.
+func (s *redisStore) Delete(ctx context.Context, opts ...DeleteOption) error {
+	var ops DeleteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	return s.client.Del(ctx, redisKey(ops.Table, ops.Key, ops.Value)).Err()
+}
+
+// Indexes are not supported by the redis store.
+func (s *redisStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	return nil
+}
+
+// Checks database connectivity.
+func (s *redisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Returns db options.
+func (s *redisStore) Options() store.Options {
+	return s.options
+}
+
+// Returns adapter name.
+func (s *redisStore) String() string {
+	return "redis"
+}
+
+// redisKey joins non-empty parts into a table namespaced key.
+func redisKey(table string, parts ...string) string {
+	key := table + ":"
+	for _, part := range parts {
+		if part != "" {
+			key += part + ":"
+		}
+	}
+
+	return strings.TrimSuffix(key, ":")
+}
+
+// paginationBounds returns the slice bounds of a page of n elements.
+// Zero limit means no limit.
+func paginationBounds(n int, offset, limit uint) (int, int) {
+	if int(offset) >= n {
+		return n, n
+	}
+
+	hi := n
+	if limit > 0 && int(offset+limit) < n {
+		hi = int(offset + limit)
+	}
+
+	return int(offset), hi
+}
+
+// paginateResult slices a pointer to a slice result in place.
+func paginateResult(result any, offset, limit uint) {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return
+	}
+
+	rv = rv.Elem()
+	lo, hi := paginationBounds(rv.Len(), offset, limit)
+	rv.Set(rv.Slice(lo, hi))
+}
+
+// redisPayloadKey builds a record key. Uses the record's key for
+// *store.Record payloads and write options otherwise. Payloads without
+// a key are rejected rather than written to the bare table key.
+func redisPayloadKey(ops WriteOptions, payload any) (string, error) {
+	if p, ok := payload.(*store.Record); ok && p.Key != "" {
+		return redisKey(ops.Table, p.Key), nil
+	}
+
+	if ops.Key == "" && ops.Value == "" {
+		return "", _errMissingWriteKey
+	}
+
+	return redisKey(ops.Table, ops.Key, ops.Value), nil
+}
+
+// redisEscapePattern escapes SCAN glob characters so that
+// prefixes and suffixes are matched literally.
+func redisEscapePattern(val string) string {
+	var b strings.Builder
+	for _, r := range val {
+		switch r {
+		case '\\', '*', '?', '[', ']':
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// redisExpiration converts write options into a redis expiration.
+// TTL takes precedence over Expiry. Reports expired when Expiry is
+// in the past. Positive expirations are clamped to at least 1ms,
+// zero means no expiration.
+func redisExpiration(ops WriteOptions) (time.Duration, bool) {
+	var expiration time.Duration
+	switch {
+	case ops.TTL > 0:
+		expiration = ops.TTL
+	case !ops.Expiry.IsZero():
+		if expiration = time.Until(ops.Expiry); expiration <= 0 {
+			return 0, true
+		}
+	default:
+		return 0, false
+	}
+
+	if expiration < time.Millisecond {
+		expiration = time.Millisecond
+	}
+
+	return expiration, false
+}
@@ -0,0 +1,272 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go-micro.dev/v4/store"
+)
+
+func newTestRedisStore(t *testing.T) (RefinedStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	s := NewRedisStore()
+	if err := s.Init(store.Nodes(mr.Addr())); err != nil {
+		t.Fatalf("could not init redis store: %v", err)
+	}
+
+	return s, mr
+}
+
+func TestRedisExpiration(t *testing.T) {
+	tests := []struct {
+		name       string
+		ops        WriteOptions
+		expiration time.Duration
+		expired    bool
+	}{
+		{name: "no expiration", ops: WriteOptions{}},
+		{name: "ttl", ops: WriteOptions{TTL: time.Minute}, expiration: time.Minute},
+		{name: "ttl clamped", ops: WriteOptions{TTL: time.Microsecond}, expiration: time.Millisecond},
+		{name: "past expiry", ops: WriteOptions{Expiry: time.Now().Add(-time.Minute)}, expired: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiration, expired := redisExpiration(tt.ops)
+			if expiration != tt.expiration || expired != tt.expired {
+				t.Fatalf("expected (%v, %v), got (%v, %v)", tt.expiration, tt.expired, expiration, expired)
+			}
+		})
+	}
+
+	if expiration, _ := redisExpiration(WriteOptions{Expiry: time.Now().Add(time.Hour)}); expiration <= 0 || expiration > time.Hour {
+		t.Fatalf("expected a future expiry to be converted into a positive ttl, got %v", expiration)
+	}
+}
+
+func TestRedisWritePastExpiry(t *testing.T) {
+	s, mr := newTestRedisStore(t)
+	ctx := context.Background()
+
+	if err := s.Write(ctx, &store.Record{Key: "doc", Value: []byte("v")}, WriteTo("", "docs")); err != nil {
+		t.Fatalf("could not write a record: %v", err)
+	}
+
+	if err := s.Write(
+		ctx, &store.Record{Key: "doc", Value: []byte("v")}, WriteTo("", "docs"),
+		WriteExpiry(time.Now().Add(-time.Second)),
+	); err != nil {
+		t.Fatalf("could not write an expired record: %v", err)
+	}
+
+	if mr.Exists("docs:doc") {
+		t.Fatal("expected an already expired record to be removed")
+	}
+}
+
+func TestRedisWriteTTL(t *testing.T) {
+	s, mr := newTestRedisStore(t)
+	if err := s.Write(
+		context.Background(), &store.Record{Key: "doc", Value: []byte("v")},
+		WriteTo("", "docs"), WriteTTL(time.Minute),
+	); err != nil {
+		t.Fatalf("could not write a record: %v", err)
+	}
+
+	if ttl := mr.TTL("docs:doc"); ttl != time.Minute {
+		t.Fatalf("expected a minute ttl, got %v", ttl)
+	}
+
+	mr.FastForward(2 * time.Minute)
+	if mr.Exists("docs:doc") {
+		t.Fatal("expected the record to expire")
+	}
+}
+
+func TestRedisExists(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	if err := s.Write(ctx, &store.Record{Key: "doc", Value: []byte("v")}, WriteTo("", "docs")); err != nil {
+		t.Fatalf("could not write a record: %v", err)
+	}
+
+	for key, exists := range map[string]bool{"doc": true, "missing": false} {
+		found, err := s.Exists(ctx, ReadFrom("", "docs"), ReadKey(key))
+		if err != nil {
+			t.Fatalf("could not check %s: %v", key, err)
+		}
+
+		if found != exists {
+			t.Fatalf("expected %s existence to be %v, got %v", key, exists, found)
+		}
+	}
+}
+
+func TestRedisReadNotFound(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	var record store.Record
+	err := s.Read(context.Background(), ReadFrom("", "docs"), ReadKey("missing"), ReadResult(&record))
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, redis.Nil) {
+		t.Fatalf("expected a wrapped not found error, got %v", err)
+	}
+}
+
+type redisTestDoc struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	Kind  string `json:"kind"`
+}
+
+func TestRedisListFilterLimit(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	docs := []redisTestDoc{
+		{ID: "1", Owner: "bob", Kind: "text"},
+		{ID: "2", Owner: "alice", Kind: "text"},
+		{ID: "3", Owner: "alice", Kind: "sheet"},
+		{ID: "4", Owner: "alice", Kind: "text"},
+		{ID: "5", Owner: "alice", Kind: "text"},
+	}
+
+	for _, doc := range docs {
+		if err := s.Write(ctx, doc, WriteTo("", "docs"), WriteKey(doc.ID)); err != nil {
+			t.Fatalf("could not write %s: %v", doc.ID, err)
+		}
+	}
+
+	var result []redisTestDoc
+	if err := s.List(
+		ctx, ReadFrom("", "docs"), ReadLimit(2),
+		ReadFilter(map[string]any{"owner": "alice", "kind": "text"}),
+		ReadResult(&result),
+	); err != nil {
+		t.Fatalf("could not list records: %v", err)
+	}
+
+	if len(result) != 2 || result[0].ID != "2" || result[1].ID != "4" {
+		t.Fatalf("expected the first two matching records, got %v", result)
+	}
+}
+
+func TestRedisWriteRead(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	doc := redisTestDoc{ID: "1", Owner: "alice", Kind: "text"}
+	if err := s.Write(ctx, doc, WriteTo("", "docs"), WriteKey(doc.ID)); err != nil {
+		t.Fatalf("could not write a record: %v", err)
+	}
+
+	var result redisTestDoc
+	if err := s.Read(ctx, ReadFrom("", "docs"), ReadKey(doc.ID), ReadResult(&result)); err != nil {
+		t.Fatalf("could not read a record: %v", err)
+	}
+
+	if result != doc {
+		t.Fatalf("expected %v, got %v", doc, result)
+	}
+}
+
+func TestRedisWriteMissingKey(t *testing.T) {
+	s, mr := newTestRedisStore(t)
+	ctx := context.Background()
+	if err := s.Write(ctx, redisTestDoc{ID: "1"}, WriteTo("", "docs")); !errors.Is(err, _errMissingWriteKey) {
+		t.Fatalf("expected a missing key error, got %v", err)
+	}
+
+	if err := s.Update(ctx, redisTestDoc{ID: "1"}, WriteTo("", "docs"), WriteUpsert(true)); !errors.Is(err, _errMissingWriteKey) {
+		t.Fatalf("expected a missing key error on update, got %v", err)
+	}
+
+	if mr.Exists("docs") {
+		t.Fatal("expected nothing to be written to the bare table key")
+	}
+}
+
+func TestRedisListPrefixSuffix(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	for _, id := range []string{"a*1.docx", "a*2.xlsx", "ab3.docx", "b*4.docx"} {
+		if err := s.Write(ctx, redisTestDoc{ID: id}, WriteTo("", "docs"), WriteKey(id)); err != nil {
+			t.Fatalf("could not write %s: %v", id, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts []ReadOption
+		ids  []string
+	}{
+		{name: "prefix", opts: []ReadOption{ReadPrefix("a*")}, ids: []string{"a*1.docx", "a*2.xlsx"}},
+		{name: "suffix", opts: []ReadOption{ReadSuffix(".docx")}, ids: []string{"a*1.docx", "ab3.docx", "b*4.docx"}},
+		{name: "prefix and suffix", opts: []ReadOption{ReadPrefix("a*"), ReadSuffix(".docx")}, ids: []string{"a*1.docx"}},
+		{name: "glob prefix", opts: []ReadOption{ReadPrefix("?")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []redisTestDoc
+			opts := append([]ReadOption{ReadFrom("", "docs"), ReadResult(&result)}, tt.opts...)
+			if err := s.List(ctx, opts...); err != nil {
+				t.Fatalf("could not list records: %v", err)
+			}
+
+			if len(result) != len(tt.ids) {
+				t.Fatalf("expected %v, got %v", tt.ids, result)
+			}
+
+			for i, id := range tt.ids {
+				if result[i].ID != id {
+					t.Fatalf("expected %v, got %v", tt.ids, result)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisUpdate(t *testing.T) {
+	s, _ := newTestRedisStore(t)
+	ctx := context.Background()
+	if err := s.Update(ctx, redisTestDoc{ID: "1"}, WriteTo("", "docs"), WriteKey("1")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a not found error without upsert, got %v", err)
+	}
+
+	if err := s.Write(ctx, redisTestDoc{ID: "1", Owner: "alice", Kind: "text"}, WriteTo("", "docs"), WriteKey("1")); err != nil {
+		t.Fatalf("could not write a record: %v", err)
+	}
+
+	if err := s.Update(ctx, map[string]any{"kind": "sheet"}, WriteTo("", "docs"), WriteKey("1")); err != nil {
+		t.Fatalf("could not update a record: %v", err)
+	}
+
+	var result redisTestDoc
+	if err := s.Read(ctx, ReadFrom("", "docs"), ReadKey("1"), ReadResult(&result)); err != nil {
+		t.Fatalf("could not read a record: %v", err)
+	}
+
+	if expected := (redisTestDoc{ID: "1", Owner: "alice", Kind: "sheet"}); result != expected {
+		t.Fatalf("expected the update to be merged into %v, got %v", expected, result)
+	}
+}
@@ -27,11 +27,21 @@ package storage
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type StorageType int
+
+var (
+	Empty StorageType = 0
+	Mongo StorageType = 1
+	Redis StorageType = 2
 )
 
 type ReadOption func(l *ReadOptions)
@@ -43,6 +53,9 @@ type ReadOptions struct {
 	Key string
 	// Value to read (optional).
 	Value string
+	// Values is a list of Key values to match any of (optional).
+	// Takes precedence over Value.
+	Values []string
 	// Prefix returns all keys that are prefixed with key.
 	Prefix string
 	// Suffix returns all keys that end with key.
@@ -51,6 +64,19 @@ type ReadOptions struct {
 	Limit uint
 	// Offset when combined with Limit supports pagination.
 	Offset uint
+	// SortField is a field name to sort the result by (optional).
+	SortField string
+	// SortAsc is a sort direction flag. Used only when SortField is set.
+	SortAsc bool
+	// Projection is a list of fields to return (optional).
+	Projection []string
+	// Filter is a set of field names and values every returned record
+	// must match (optional). Merged with Key and Value (Key and Values
+	// for lists).
+	Filter map[string]any
+	// IncludeDeleted is a flag to return soft-deleted records (optional).
+	// Used only for tables with soft delete enabled.
+	IncludeDeleted bool
 	// Result from the executed query.
 	Result any
 }
@@ -77,6 +103,13 @@ func ReadValue(val string) ReadOption {
 	}
 }
 
+// Sets a list of filter field's values to match any of.
+func ReadValues(values ...string) ReadOption {
+	return func(l *ReadOptions) {
+		l.Values = values
+	}
+}
+
 // Sets a filter prefix value.
 func ReadPrefix(val string) ReadOption {
 	return func(l *ReadOptions) {
@@ -105,6 +138,36 @@ func ReadOffset(val uint) ReadOption {
 	}
 }
 
+// Sets a sort field and direction.
+func ReadSort(field string, asc bool) ReadOption {
+	return func(l *ReadOptions) {
+		l.SortField = field
+		l.SortAsc = asc
+	}
+}
+
+// Sets a list of fields to return.
+func ReadProject(fields ...string) ReadOption {
+	return func(l *ReadOptions) {
+		l.Projection = fields
+	}
+}
+
+// Sets a multi-field filter. Every returned record must match all
+// the fields.
+func ReadFilter(val map[string]any) ReadOption {
+	return func(l *ReadOptions) {
+		l.Filter = val
+	}
+}
+
+// Sets a flag to return soft-deleted records.
+func ReadIncludeDeleted(val bool) ReadOption {
+	return func(l *ReadOptions) {
+		l.IncludeDeleted = val
+	}
+}
+
 // Sets a pointer to populate it with the result.
 func ReadResult(val any) ReadOption {
 	return func(l *ReadOptions) {
@@ -126,6 +189,9 @@ type WriteOptions struct {
 	Expiry time.Time
 	// TTL is the time until the record expires.
 	TTL time.Duration
+	// Upsert is a flag to insert a new record if the record to update
+	// is not found (optional).
+	Upsert bool
 }
 
 // Sets database and database table.
@@ -164,11 +230,21 @@ func WriteTTL(val time.Duration) WriteOption {
 	}
 }
 
+// Sets upsert flag.
+func WriteUpsert(val bool) WriteOption {
+	return func(w *WriteOptions) {
+		w.Upsert = val
+	}
+}
+
 // DeleteOptions configures an individual Delete operation.
 type DeleteOptions struct {
 	Database, Table string
 	Key             string
 	Value           string
+	// Soft is a flag to mark a record as deleted instead of removing it
+	// (optional). Not supported by every store.
+	Soft bool
 }
 
 // DeleteOption sets values in DeleteOptions.
@@ -196,41 +272,121 @@ func DeleteValue(val string) DeleteOption {
 	}
 }
 
+// Sets soft delete flag.
+func DeleteSoft(val bool) DeleteOption {
+	return func(d *DeleteOptions) {
+		d.Soft = val
+	}
+}
+
+type readPreferenceKey struct{}
+type writeConcernKey struct{}
+type softDeleteKey struct{}
+
+// Sets a MongoDB read preference mode (primary, secondary, nearest etc).
+func WithReadPreference(mode string) store.Option {
+	return withContextValue(readPreferenceKey{}, mode)
+}
+
+// Sets a MongoDB write concern (majority or w<N>).
+func WithWriteConcern(concern string) store.Option {
+	return withContextValue(writeConcernKey{}, concern)
+}
+
+// Enables soft delete for the tables provided, or for every table if none
+// are provided or one of them is "*". Soft-deleted records of such tables
+// are hidden from reads unless explicitly included.
+func WithSoftDelete(tables ...string) store.Option {
+	if len(tables) == 0 {
+		tables = []string{"*"}
+	}
+
+	return withContextValue(softDeleteKey{}, tables)
+}
+
+func withContextValue(key, val any) store.Option {
+	return func(o *store.Options) {
+		if o.Context == nil {
+			o.Context = context.Background()
+		}
+
+		o.Context = context.WithValue(o.Context, key, val)
+	}
+}
+
 // RefinedStore is a go-micro store.Store wrapper
 // to allow SQL and No-SQL database operations.
 type RefinedStore interface {
 	Init(opts ...store.Option) error
 	List(ctx context.Context, opts ...ReadOption) error
 	Read(ctx context.Context, opts ...ReadOption) error
+	Exists(ctx context.Context, opts ...ReadOption) (bool, error)
 	Write(ctx context.Context, payload any, opts ...WriteOption) error
+	WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error
 	Update(ctx context.Context, payload any, opts ...WriteOption) error
 	Delete(ctx context.Context, opts ...DeleteOption) error
+	Ping(ctx context.Context) error
 	Options() store.Options
 	String() string
 }
 
+// IndexedStore is an optional RefinedStore extension used to declare
+// indexes alongside the store. Stores without index support implement
+// it as a no-op.
+type IndexedStore interface {
+	EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error
+}
+
+// TransactionalStore is an optional RefinedStore extension used to run
+// several operations atomically. Operations must be called on tx.
+type TransactionalStore interface {
+	WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error
+}
+
 // A RefinedStore constructor. Called automatically by fx and
 // bootstrapper.
 //
 // Returns a RefinedStore compliant implementation based
 // on persistence configuration.
 //
-// By default - empty adapter
-func NewStorage(config *config.StorageConfig) RefinedStore {
+// By default - empty adapter. Returns the first error encountered
+// during store initialization.
+func NewStorage(config *config.StorageConfig) (RefinedStore, error) {
 	var s RefinedStore
-	switch config.Storage.Type {
-	case 1:
+	switch StorageType(config.Storage.Type) {
+	case Mongo:
 		s = NewMongoStore()
+	case Redis:
+		s = NewRedisStore()
 	default:
 		s = NewEmptyStore()
 	}
 
-	if err := s.Init(
+	opts := []store.Option{
 		store.Database(config.Storage.DB),
 		store.Nodes(config.Storage.URL),
-	); err != nil {
-		log.Fatalln(err.Error())
+		WithReadPreference(config.Storage.ReadPreference),
+		WithWriteConcern(config.Storage.WriteConcern),
+	}
+
+	if len(config.Storage.SoftDelete) > 0 {
+		opts = append(opts, WithSoftDelete(config.Storage.SoftDelete...))
+	}
+
+	if err := s.Init(opts...); err != nil {
+		return nil, fmt.Errorf("could not initialize %s storage with url '%s': %w", s.String(), redactURL(config.Storage.URL), err)
+	}
+
+	return s, nil
+}
+
+// redactURL hides url credentials. Unparsable urls are replaced
+// with the config key as they may contain credentials as well.
+func redactURL(val string) string {
+	u, err := url.Parse(val)
+	if err != nil {
+		return "storage.url/STORAGE_URL"
 	}
 
-	return s
+	return u.Redacted()
 }
@@ -0,0 +1,223 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package storage provides a store wrapper over go-micro's store.Store and
+// several implementations.
+//
+// The store package's structures are self-initialized by fx and bootstrapper.
+// Fields are populated via yaml values or env variables. Env variables overwrite
+// yaml configuration.
+package storage
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/store"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedStore struct {
+	inner  RefinedStore
+	tracer trace.Tracer
+}
+
+// NewTracedStore wraps a RefinedStore to start a span per operation
+// (storage.Read, storage.Write etc.) tagged with database and table names.
+// Returns the inner store as is when tracing is disabled.
+//
+// Composable with NewInstrumentedStore.
+func NewTracedStore(
+	inner RefinedStore,
+	provider trace.TracerProvider,
+	tracerConfig *config.TracerConfig,
+) RefinedStore {
+	if provider == nil || !tracerConfig.Tracer.Enable {
+		return inner
+	}
+
+	return &tracedStore{
+		inner:  inner,
+		tracer: provider.Tracer("storage"),
+	}
+}
+
+func (s *tracedStore) start(
+	ctx context.Context, method, database, table string,
+) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "storage."+method, trace.WithAttributes(
+		attribute.String("storage.type", s.inner.String()),
+		attribute.String("storage.database", database),
+		attribute.String("storage.table", table),
+	))
+}
+
+func (s *tracedStore) end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func (s *tracedStore) Init(opts ...store.Option) error {
+	return s.inner.Init(opts...)
+}
+
+func (s *tracedStore) List(ctx context.Context, opts ...ReadOption) error {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "List", ops.Database, ops.Table)
+	err := s.inner.List(ctx, opts...)
+	if err == nil {
+		span.SetAttributes(attribute.Int("storage.results", resultCount(ops.Result)))
+	}
+
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) Read(ctx context.Context, opts ...ReadOption) error {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "Read", ops.Database, ops.Table)
+	err := s.inner.Read(ctx, opts...)
+	if err == nil {
+		span.SetAttributes(attribute.Int("storage.results", resultCount(ops.Result)))
+	}
+
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) Exists(ctx context.Context, opts ...ReadOption) (bool, error) {
+	var ops ReadOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "Exists", ops.Database, ops.Table)
+	exists, err := s.inner.Exists(ctx, opts...)
+	span.SetAttributes(attribute.Bool("storage.exists", exists))
+	s.end(span, err)
+	return exists, err
+}
+
+func (s *tracedStore) Write(ctx context.Context, payload any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "Write", ops.Database, ops.Table)
+	err := s.inner.Write(ctx, payload, opts...)
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) WriteMany(ctx context.Context, payloads []any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "WriteMany", ops.Database, ops.Table)
+	span.SetAttributes(attribute.Int("storage.payloads", len(payloads)))
+	err := s.inner.WriteMany(ctx, payloads, opts...)
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) Update(ctx context.Context, payload any, opts ...WriteOption) error {
+	var ops WriteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "Update", ops.Database, ops.Table)
+	err := s.inner.Update(ctx, payload, opts...)
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) Delete(ctx context.Context, opts ...DeleteOption) error {
+	var ops DeleteOptions
+	for _, o := range opts {
+		o(&ops)
+	}
+
+	ctx, span := s.start(ctx, "Delete", ops.Database, ops.Table)
+	err := s.inner.Delete(ctx, opts...)
+	s.end(span, err)
+	return err
+}
+
+func (s *tracedStore) WithTransaction(ctx context.Context, fn func(tx RefinedStore) error) error {
+	transactional, ok := s.inner.(TransactionalStore)
+	if !ok {
+		return ErrTransactionsNotSupported
+	}
+
+	return transactional.WithTransaction(ctx, func(tx RefinedStore) error {
+		return fn(&tracedStore{inner: tx, tracer: s.tracer})
+	})
+}
+
+func (s *tracedStore) EnsureIndexes(ctx context.Context, table string, models []mongo.IndexModel) error {
+	if indexed, ok := s.inner.(IndexedStore); ok {
+		return indexed.EnsureIndexes(ctx, table, models)
+	}
+
+	return nil
+}
+
+func (s *tracedStore) Ping(ctx context.Context) error {
+	return s.inner.Ping(ctx)
+}
+
+func (s *tracedStore) Options() store.Options {
+	return s.inner.Options()
+}
+
+func (s *tracedStore) String() string {
+	return s.inner.String()
+}
+
+// resultCount returns a decoded slice length or 1 for a single result.
+func resultCount(result any) int {
+	rv := indirectValue(reflect.ValueOf(result))
+	switch {
+	case !rv.IsValid():
+		return 0
+	case rv.Kind() == reflect.Slice:
+		return rv.Len()
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,103 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go-micro.dev/v4/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestTracedStore(t *testing.T) (RefinedStore, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	var tracerConfig config.TracerConfig
+	tracerConfig.Tracer.Enable = true
+	return NewTracedStore(NewMemoryStore(), provider, &tracerConfig), recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+
+	return attribute.Value{}
+}
+
+func TestTracedStoreSpans(t *testing.T) {
+	s, recorder := newTestTracedStore(t)
+	ctx := context.Background()
+	if err := s.Write(ctx, &store.Record{Key: "doc", Value: []byte("value")}, WriteTo("db", "docs")); err != nil {
+		t.Fatalf("could not write a record: %s", err.Error())
+	}
+
+	var keys []string
+	if err := s.List(ctx, ReadFrom("db", "docs"), ReadResult(&keys)); err != nil {
+		t.Fatalf("could not list records: %s", err.Error())
+	}
+
+	var records []*store.Record
+	if err := s.Read(ctx, ReadFrom("db", "docs"), ReadKey("missing"), ReadResult(&records)); err == nil {
+		t.Fatal("expected a missing record error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected a span per call, got %d", len(spans))
+	}
+
+	for i, name := range []string{"storage.Write", "storage.List", "storage.Read"} {
+		if spans[i].Name() != name {
+			t.Fatalf("expected span %s, got %s", name, spans[i].Name())
+		}
+
+		if spanAttribute(spans[i], "storage.table").AsString() != "docs" ||
+			spanAttribute(spans[i], "storage.database").AsString() != "db" {
+			t.Fatalf("expected %s span to be tagged with database and table", name)
+		}
+	}
+
+	if spanAttribute(spans[1], "storage.results").AsInt64() != 1 {
+		t.Fatal("expected the list span to carry the result count")
+	}
+
+	if spans[2].Status().Code != codes.Error {
+		t.Fatal("expected the read span to record an error")
+	}
+}
+
+func TestTracedStoreDisabled(t *testing.T) {
+	inner := NewMemoryStore()
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	if s := NewTracedStore(inner, provider, &config.TracerConfig{}); s != inner {
+		t.Fatal("expected the inner store when tracing is disabled")
+	}
+}
@@ -0,0 +1,46 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trace
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewOTLPExporter creates a new OTLP/gRPC exporter. It is also used for Jaeger,
+// which ingests OTLP natively (the dedicated Jaeger exporter is deprecated
+// upstream).
+//
+// The address is either a collector URL (http:// endpoints are insecure)
+// or a plain host:port pair, which is dialed without TLS.
+func NewOTLPExporter(address string, opts ...otlptracegrpc.Option) (trace.SpanExporter, error) {
+	if strings.Contains(address, "://") {
+		opts = append([]otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(address)}, opts...)
+	} else {
+		opts = append([]otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(address),
+			otlptracegrpc.WithInsecure(),
+		}, opts...)
+	}
+
+	return otlptracegrpc.New(context.Background(), opts...)
+}
@@ -21,6 +21,7 @@ package trace
 import (
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -33,12 +34,22 @@ type TracerType int
 var (
 	Default TracerType = 0
 	Zipkin  TracerType = 1
+	OTLP    TracerType = 2
+	Jaeger  TracerType = 3
 )
 
-// NewTracer initializes a new tracer.
-func NewTracer(config *config.TracerConfig) (*trace.TracerProvider, error) {
+// NewTracer initializes a new tracer. Spans carry service name, namespace and
+// version resource attributes taken from the server configuration.
+func NewTracer(
+	serverConfig *config.ServerConfig,
+	config *config.TracerConfig,
+) (*trace.TracerProvider, error) {
 	var exporter trace.SpanExporter
 
+	if config.Tracer.Name == "" {
+		config.Tracer.Name = serverConfig.Name
+	}
+
 	if config.Tracer.Name == "" {
 		config.Tracer.Name = "default-tracer"
 	}
@@ -49,6 +60,15 @@ func NewTracer(config *config.TracerConfig) (*trace.TracerProvider, error) {
 			return nil, ErrTracerInvalidAddressInitialization
 		}
 		exporter = NewZipkinExporter(config.Tracer.Address)
+	case 2, 3:
+		if config.Tracer.Address == "" {
+			return nil, ErrTracerInvalidAddressInitialization
+		}
+
+		var err error
+		if exporter, err = NewOTLPExporter(config.Tracer.Address); err != nil {
+			return nil, err
+		}
 	default:
 		exporter, _ = stdouttrace.New()
 	}
@@ -56,10 +76,7 @@ func NewTracer(config *config.TracerConfig) (*trace.TracerProvider, error) {
 	provider := trace.NewTracerProvider(
 		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(config.Tracer.FractionRatio))),
 		trace.WithBatcher(exporter),
-		trace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(config.Tracer.Name),
-		)),
+		trace.WithResource(newResource(config.Tracer.Name, serverConfig)),
 	)
 
 	otel.SetTracerProvider(provider)
@@ -72,3 +89,18 @@ func NewTracer(config *config.TracerConfig) (*trace.TracerProvider, error) {
 
 	return provider, nil
 }
+
+// newResource builds an OpenTelemetry resource describing the current service.
+// Empty namespace and version are omitted.
+func newResource(name string, serverConfig *config.ServerConfig) *resource.Resource {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(name)}
+	if serverConfig.Namespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespaceKey.String(serverConfig.Namespace))
+	}
+
+	if serverConfig.Version != "" {
+		attrs = append(attrs, semconv.ServiceVersionKey.String(serverConfig.Version))
+	}
+
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
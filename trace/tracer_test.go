@@ -0,0 +1,95 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestNewTracerOTLP(t *testing.T) {
+	var tracerConfig config.TracerConfig
+	tracerConfig.Tracer.TracerType = int(OTLP)
+	tracerConfig.Tracer.Address = "localhost:4317"
+	tracerConfig.Tracer.FractionRatio = 1
+
+	provider, err := NewTracer(&config.ServerConfig{Name: "tracer"}, &tracerConfig)
+	if err != nil {
+		t.Fatalf("could not build an otlp tracer: %s", err.Error())
+	}
+
+	defer provider.Shutdown(context.Background())
+}
+
+func TestNewTracerOTLPWithoutAddress(t *testing.T) {
+	var tracerConfig config.TracerConfig
+	tracerConfig.Tracer.TracerType = int(OTLP)
+
+	if _, err := NewTracer(&config.ServerConfig{}, &tracerConfig); err != ErrTracerInvalidAddressInitialization {
+		t.Fatalf("expected an invalid address error, got %v", err)
+	}
+}
+
+func TestNewTracerResourceAttributes(t *testing.T) {
+	var tracerConfig config.TracerConfig
+	tracerConfig.Tracer.TracerType = int(Jaeger)
+	tracerConfig.Tracer.Address = "localhost:4317"
+	tracerConfig.Tracer.FractionRatio = 1
+
+	provider, err := NewTracer(&config.ServerConfig{
+		Namespace: "onlyoffice",
+		Name:      "docs",
+		Version:   "1.2.3",
+	}, &tracerConfig)
+	if err != nil {
+		t.Fatalf("could not build a jaeger tracer: %s", err.Error())
+	}
+
+	defer provider.Shutdown(context.Background())
+
+	recorder := tracetest.NewSpanRecorder()
+	provider.RegisterSpanProcessor(recorder)
+	_, span := provider.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected a single sampled span, got %d", len(spans))
+	}
+
+	attrs := make(map[attribute.Key]string)
+	for _, attr := range spans[0].Resource().Attributes() {
+		attrs[attr.Key] = attr.Value.Emit()
+	}
+
+	for key, expected := range map[attribute.Key]string{
+		semconv.ServiceNameKey:      "docs",
+		semconv.ServiceNamespaceKey: "onlyoffice",
+		semconv.ServiceVersionKey:   "1.2.3",
+	} {
+		if attrs[key] != expected {
+			t.Fatalf("expected %s resource attribute %q, got %q", key, expected, attrs[key])
+		}
+	}
+}
@@ -21,7 +21,6 @@ package worker
 import (
 	"context"
 	"log"
-	"time"
 
 	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
 	plog "github.com/ONLYOFFICE/onlyoffice-integration-adapters/log"
@@ -41,34 +40,22 @@ type asynqEnqueuer struct {
 	inspector *asynq.Inspector
 }
 
-func newAsynqWorker(config *config.WorkerConfig, logger plog.Logger) BackgroundWorker {
-	var workerOpts asynq.RedisConnOpt = asynq.RedisClientOpt{
-		Addr:         config.Worker.RedisAddresses[0],
-		Username:     config.Worker.RedisUsername,
-		Password:     config.Worker.RedisPassword,
-		DB:           config.Worker.RedisDatabase,
-		ReadTimeout:  4 * time.Second,
-		WriteTimeout: 7 * time.Second,
-	}
-	if len(config.Worker.RedisAddresses) > 1 {
-		workerOpts = asynq.RedisClusterClientOpt{
-			Addrs:        config.Worker.RedisAddresses,
-			Username:     config.Worker.RedisUsername,
-			Password:     config.Worker.RedisPassword,
-			ReadTimeout:  4 * time.Second,
-			WriteTimeout: 7 * time.Second,
-		}
+func newAsynqWorker(config *config.WorkerConfig, logger plog.Logger) (BackgroundWorker, error) {
+	workerOpts, err := newRedisConnOpt(config)
+	if err != nil {
+		return nil, err
 	}
 
 	return asynqWorker{
 		enabled: config.Worker.Enable,
 		srv: asynq.NewServer(workerOpts, asynq.Config{
 			Concurrency: config.Worker.MaxConcurrency,
+			Queues:      config.Worker.Queues,
 			Logger:      logger,
 		}),
 		mux:       asynq.NewServeMux(),
 		inspector: asynq.NewInspector(workerOpts),
-	}
+	}, nil
 }
 
 func (w asynqWorker) Register(pattern string, handler func(ctx context.Context, payload []byte) error, cleanups ...func(taskID string, payload []byte)) {
@@ -76,7 +63,12 @@ func (w asynqWorker) Register(pattern string, handler func(ctx context.Context,
 		w.mux.Handle(pattern, asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
 			herr := handler(ctx, t.Payload())
 
-			if info, err := w.inspector.GetTaskInfo("default", t.ResultWriter().TaskID()); herr != nil && err == nil && info.Retried == info.MaxRetry {
+			queue, ok := asynq.GetQueueName(ctx)
+			if !ok {
+				queue = "default"
+			}
+
+			if info, err := w.inspector.GetTaskInfo(queue, t.ResultWriter().TaskID()); herr != nil && err == nil && info.Retried == info.MaxRetry {
 				for _, cleanup := range cleanups {
 					cleanup(t.ResultWriter().TaskID(), t.Payload())
 				}
@@ -97,30 +89,17 @@ func (w asynqWorker) Run() {
 	}
 }
 
-func newAsynqEnqueuer(config *config.WorkerConfig) BackgroundEnqueuer {
-	var enqOpts asynq.RedisConnOpt = asynq.RedisClientOpt{
-		Addr:         config.Worker.RedisAddresses[0],
-		Username:     config.Worker.RedisUsername,
-		Password:     config.Worker.RedisPassword,
-		DB:           config.Worker.RedisDatabase,
-		ReadTimeout:  4 * time.Second,
-		WriteTimeout: 7 * time.Second,
-	}
-	if len(config.Worker.RedisAddresses) > 1 {
-		enqOpts = asynq.RedisClusterClientOpt{
-			Addrs:        config.Worker.RedisAddresses,
-			Username:     config.Worker.RedisUsername,
-			Password:     config.Worker.RedisPassword,
-			ReadTimeout:  4 * time.Second,
-			WriteTimeout: 7 * time.Second,
-		}
+func newAsynqEnqueuer(config *config.WorkerConfig) (BackgroundEnqueuer, error) {
+	enqOpts, err := newRedisConnOpt(config)
+	if err != nil {
+		return nil, err
 	}
 
 	return asynqEnqueuer{
 		enabled:   config.Worker.Enable,
 		client:    asynq.NewClient(enqOpts),
 		inspector: asynq.NewInspector(enqOpts),
-	}
+	}, nil
 }
 
 func (e asynqEnqueuer) Enqueue(pattern string, task []byte, opts ...EnqueuerOption) error {
@@ -128,10 +107,11 @@ func (e asynqEnqueuer) Enqueue(pattern string, task []byte, opts ...EnqueuerOpti
 		options := NewEnqueuerOptions(opts...)
 		t := asynq.NewTask(pattern, task)
 
-		e.inspector.DeleteTask("default", options.TaskID)
+		e.inspector.DeleteTask(options.Queue, options.TaskID)
 		_, err := e.client.Enqueue(
 			t, asynq.MaxRetry(options.MaxRetry), asynq.Timeout(options.Timeout),
-			asynq.TaskID(options.TaskID),
+			asynq.TaskID(options.TaskID), asynq.Queue(options.Queue),
+			asynq.ProcessIn(options.Delay),
 		)
 
 		return err
@@ -145,10 +125,11 @@ func (e asynqEnqueuer) EnqueueContext(ctx context.Context, pattern string, task
 		options := NewEnqueuerOptions(opts...)
 		t := asynq.NewTask(pattern, task)
 
-		e.inspector.DeleteTask("default", options.TaskID)
+		e.inspector.DeleteTask(options.Queue, options.TaskID)
 		_, err := e.client.EnqueueContext(
 			ctx, t, asynq.MaxRetry(options.MaxRetry), asynq.Timeout(options.Timeout),
-			asynq.TaskID(options.TaskID),
+			asynq.TaskID(options.TaskID), asynq.Queue(options.Queue),
+			asynq.ProcessIn(options.Delay),
 		)
 
 		return err
@@ -157,9 +138,79 @@ func (e asynqEnqueuer) EnqueueContext(ctx context.Context, pattern string, task
 	return nil
 }
 
+func (e asynqEnqueuer) ListArchived(opts ...InspectOption) ([]FailedTask, error) {
+	return e.list(e.inspector.ListArchivedTasks, opts...)
+}
+
+func (e asynqEnqueuer) ListRetry(opts ...InspectOption) ([]FailedTask, error) {
+	return e.list(e.inspector.ListRetryTasks, opts...)
+}
+
+func (e asynqEnqueuer) Requeue(taskID string, opts ...InspectOption) error {
+	if e.enabled {
+		options := NewInspectOptions(opts...)
+		return e.inspector.RunTask(options.Queue, taskID)
+	}
+
+	return nil
+}
+
+// list maps limit and offset onto asynq pages of limit size. Offsets
+// not aligned to the page size span two pages. The limit is capped
+// by MaxInspectLimit.
+func (e asynqEnqueuer) list(
+	fetch func(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error),
+	opts ...InspectOption,
+) ([]FailedTask, error) {
+	if !e.enabled {
+		return nil, nil
+	}
+
+	options := NewInspectOptions(opts...)
+	limit, offset := int(options.Limit), int(options.Offset)
+	page := offset/limit + 1
+	infos, err := fetch(options.Queue, asynq.PageSize(limit), asynq.Page(page))
+	if err != nil {
+		return nil, err
+	}
+
+	if skip := offset % limit; skip > 0 {
+		if len(infos) == limit {
+			next, err := fetch(options.Queue, asynq.PageSize(limit), asynq.Page(page+1))
+			if err != nil {
+				return nil, err
+			}
+
+			infos = append(infos, next...)
+		}
+
+		infos = infos[min(skip, len(infos)):]
+	}
+
+	if len(infos) > limit {
+		infos = infos[:limit]
+	}
+
+	tasks := make([]FailedTask, 0, len(infos))
+	for _, info := range infos {
+		tasks = append(tasks, FailedTask{
+			ID:           info.ID,
+			Queue:        info.Queue,
+			Type:         info.Type,
+			Payload:      info.Payload,
+			Retried:      info.Retried,
+			MaxRetry:     info.MaxRetry,
+			LastError:    info.LastErr,
+			LastFailedAt: info.LastFailedAt,
+		})
+	}
+
+	return tasks, nil
+}
+
 func (e asynqEnqueuer) Close() error {
 	if e.enabled {
-		return e.Close()
+		return e.client.Close()
 	}
 
 	return nil
@@ -0,0 +1,150 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/hibiken/asynq"
+)
+
+func newTestAsynqEnqueuer(t *testing.T) asynqEnqueuer {
+	t.Helper()
+	mr := miniredis.RunT(t)
+
+	var workerConfig config.WorkerConfig
+	workerConfig.Worker.Enable = true
+	workerConfig.Worker.RedisAddresses = []string{mr.Addr()}
+	enqueuer, err := newAsynqEnqueuer(&workerConfig)
+	if err != nil {
+		t.Fatalf("could not create an enqueuer: %v", err)
+	}
+
+	e := enqueuer.(asynqEnqueuer)
+	t.Cleanup(func() {
+		e.inspector.Close()
+		e.Close()
+	})
+
+	return e
+}
+
+// archiveTestTasks enqueues and archives n tasks. Returns all the
+// archived tasks' ids as listed by the inspector.
+func archiveTestTasks(t *testing.T, e asynqEnqueuer, n int) []string {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("task-%d", i)
+		if err := e.Enqueue("conversion", []byte(`{}`), WithTaskID(id)); err != nil {
+			t.Fatalf("could not enqueue %s: %v", id, err)
+		}
+
+		if err := e.inspector.ArchiveTask("default", id); err != nil {
+			t.Fatalf("could not archive %s: %v", id, err)
+		}
+	}
+
+	tasks, err := e.ListArchived()
+	if err != nil {
+		t.Fatalf("could not list archived tasks: %v", err)
+	}
+
+	return failedTaskIDs(tasks)
+}
+
+func failedTaskIDs(tasks []FailedTask) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	return ids
+}
+
+func TestAsynqListArchivedPagination(t *testing.T) {
+	e := newTestAsynqEnqueuer(t)
+	all := archiveTestTasks(t, e, 5)
+	if len(all) != 5 {
+		t.Fatalf("expected 5 archived tasks, got %v", all)
+	}
+
+	tests := []struct {
+		name          string
+		limit, offset uint
+		from, to      int
+	}{
+		{"first page", 2, 0, 0, 2},
+		{"aligned offset", 2, 2, 2, 4},
+		{"unaligned offset", 2, 1, 1, 3},
+		{"last partial page", 2, 4, 4, 5},
+		{"unaligned last page", 3, 4, 4, 5},
+		{"past the end", 2, 5, 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := e.ListArchived(InspectLimit(tt.limit), InspectOffset(tt.offset))
+			if err != nil {
+				t.Fatalf("could not list archived tasks: %v", err)
+			}
+
+			if ids := failedTaskIDs(tasks); !reflect.DeepEqual(ids, all[tt.from:tt.to]) {
+				t.Fatalf("expected %v, got %v", all[tt.from:tt.to], ids)
+			}
+		})
+	}
+}
+
+func TestAsynqRequeue(t *testing.T) {
+	e := newTestAsynqEnqueuer(t)
+	all := archiveTestTasks(t, e, 2)
+	if err := e.Requeue(all[0]); err != nil {
+		t.Fatalf("could not requeue a task: %v", err)
+	}
+
+	tasks, err := e.ListArchived()
+	if err != nil {
+		t.Fatalf("could not list archived tasks: %v", err)
+	}
+
+	if ids := failedTaskIDs(tasks); !reflect.DeepEqual(ids, all[1:]) {
+		t.Fatalf("expected the requeued task to leave the archive, got %v", ids)
+	}
+
+	info, err := e.inspector.GetTaskInfo("default", all[0])
+	if err != nil {
+		t.Fatalf("could not get a requeued task: %v", err)
+	}
+
+	if info.State != asynq.TaskStatePending {
+		t.Fatalf("expected a pending task, got %s", info.State)
+	}
+}
+
+func TestNewInspectOptionsLimit(t *testing.T) {
+	for limit, expected := range map[uint]uint{0: MaxInspectLimit, 10: 10, 5000: MaxInspectLimit} {
+		if options := NewInspectOptions(InspectLimit(limit)); options.Limit != expected {
+			t.Fatalf("expected a %d limit to become %d, got %d", limit, expected, options.Limit)
+		}
+	}
+}
@@ -30,7 +30,7 @@ type BackgroundEnqueuer interface {
 	Close() error
 }
 
-func NewBackgroundEnqueuer(config *config.WorkerConfig) BackgroundEnqueuer {
+func NewBackgroundEnqueuer(config *config.WorkerConfig) (BackgroundEnqueuer, error) {
 	switch config.Worker.Type {
 	case 0:
 		return newAsynqEnqueuer(config)
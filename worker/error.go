@@ -0,0 +1,29 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import "errors"
+
+// ErrInvalidCACertificate is returned when a worker redis CA certificate
+// (worker.tls_ca_file yaml or WORKER_TLS_CA_FILE env parameter) could not be parsed.
+var ErrInvalidCACertificate = errors.New("could not parse worker redis CA certificate")
+
+// ErrInspectionNotSupported is returned when failed tasks are inspected
+// via an enqueuer without inspection support.
+var ErrInspectionNotSupported = errors.New("worker does not support task inspection")
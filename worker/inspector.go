@@ -0,0 +1,45 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import "time"
+
+// FailedTask describes a task which either failed and awaits a retry
+// or exhausted its retries and got archived.
+type FailedTask struct {
+	ID           string
+	Queue        string
+	Type         string
+	Payload      []byte
+	Retried      int
+	MaxRetry     int
+	LastError    string
+	LastFailedAt time.Time
+}
+
+// BackgroundInspector is implemented by enqueuers able to inspect and
+// requeue failed tasks.
+type BackgroundInspector interface {
+	// ListArchived lists tasks which exhausted their retries.
+	ListArchived(opts ...InspectOption) ([]FailedTask, error)
+	// ListRetry lists failed tasks awaiting a retry.
+	ListRetry(opts ...InspectOption) ([]FailedTask, error)
+	// Requeue schedules an archived or a retry task for immediate processing.
+	Requeue(taskID string, opts ...InspectOption) error
+}
@@ -43,8 +43,10 @@ type EnqueuerOption func(*EnqueuerOptions)
 
 type EnqueuerOptions struct {
 	TaskID   string
+	Queue    string
 	MaxRetry int
 	Timeout  time.Duration
+	Delay    time.Duration
 }
 
 func NewEnqueuerOptions(opts ...EnqueuerOption) EnqueuerOptions {
@@ -52,6 +54,7 @@ func NewEnqueuerOptions(opts ...EnqueuerOption) EnqueuerOptions {
 		MaxRetry: 3,
 		Timeout:  0 * time.Second,
 		TaskID:   uuid.NewString(),
+		Queue:    "default",
 	}
 
 	for _, o := range opts {
@@ -69,6 +72,14 @@ func WithTaskID(val string) EnqueuerOption {
 	}
 }
 
+func WithQueue(val string) EnqueuerOption {
+	return func(eo *EnqueuerOptions) {
+		if val != "" {
+			eo.Queue = val
+		}
+	}
+}
+
 func WithMaxRetry(val int) EnqueuerOption {
 	return func(eo *EnqueuerOptions) {
 		if val > 0 {
@@ -82,3 +93,61 @@ func WithTimeout(val time.Duration) EnqueuerOption {
 		eo.Timeout = val
 	}
 }
+
+func WithDelay(val time.Duration) EnqueuerOption {
+	return func(eo *EnqueuerOptions) {
+		if val > 0 {
+			eo.Delay = val
+		}
+	}
+}
+
+// MaxInspectLimit caps the number of tasks listed at once.
+const MaxInspectLimit = 1000
+
+type InspectOption func(*InspectOptions)
+
+type InspectOptions struct {
+	Queue string
+	// Limit limits the number of returned tasks. Zero and values
+	// above MaxInspectLimit fall back to MaxInspectLimit.
+	Limit uint
+	// Offset when combined with Limit supports pagination.
+	Offset uint
+}
+
+func NewInspectOptions(opts ...InspectOption) InspectOptions {
+	opt := InspectOptions{
+		Queue: "default",
+	}
+
+	for _, o := range opts {
+		o(&opt)
+	}
+
+	if opt.Limit == 0 || opt.Limit > MaxInspectLimit {
+		opt.Limit = MaxInspectLimit
+	}
+
+	return opt
+}
+
+func InspectQueue(val string) InspectOption {
+	return func(io *InspectOptions) {
+		if val != "" {
+			io.Queue = val
+		}
+	}
+}
+
+func InspectLimit(val uint) InspectOption {
+	return func(io *InspectOptions) {
+		io.Limit = val
+	}
+}
+
+func InspectOffset(val uint) InspectOption {
+	return func(io *InspectOptions) {
+		io.Offset = val
+	}
+}
@@ -0,0 +1,101 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"time"
+
+	"github.com/ONLYOFFICE/onlyoffice-integration-adapters/config"
+	"github.com/hibiken/asynq"
+)
+
+// newRedisConnOpt builds asynq redis connection options. Sentinel failover
+// is used when a master name is set, cluster for multiple addresses and
+// a single node client otherwise.
+func newRedisConnOpt(config *config.WorkerConfig) (asynq.RedisConnOpt, error) {
+	var tlsConfig *tls.Config
+	if config.Worker.TLS {
+		var err error
+		if tlsConfig, err = newTLSConfig(config.Worker.TLSCAFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Worker.MasterName != "" {
+		return asynq.RedisFailoverClientOpt{
+			MasterName:    config.Worker.MasterName,
+			SentinelAddrs: config.Worker.SentinelAddresses,
+			Username:      config.Worker.RedisUsername,
+			Password:      config.Worker.RedisPassword,
+			DB:            config.Worker.RedisDatabase,
+			ReadTimeout:   4 * time.Second,
+			WriteTimeout:  7 * time.Second,
+			TLSConfig:     tlsConfig,
+		}, nil
+	}
+
+	if len(config.Worker.RedisAddresses) > 1 {
+		return asynq.RedisClusterClientOpt{
+			Addrs:        config.Worker.RedisAddresses,
+			Username:     config.Worker.RedisUsername,
+			Password:     config.Worker.RedisPassword,
+			ReadTimeout:  4 * time.Second,
+			WriteTimeout: 7 * time.Second,
+			TLSConfig:    tlsConfig,
+		}, nil
+	}
+
+	var addr string
+	if len(config.Worker.RedisAddresses) > 0 {
+		addr = config.Worker.RedisAddresses[0]
+	}
+
+	return asynq.RedisClientOpt{
+		Addr:         addr,
+		Username:     config.Worker.RedisUsername,
+		Password:     config.Worker.RedisPassword,
+		DB:           config.Worker.RedisDatabase,
+		ReadTimeout:  4 * time.Second,
+		WriteTimeout: 7 * time.Second,
+		TLSConfig:    tlsConfig,
+	}, nil
+}
+
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, ErrInvalidCACertificate
+	}
+
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
@@ -30,7 +30,7 @@ type BackgroundWorker interface {
 	Run()
 }
 
-func NewBackgroundWorker(config *config.WorkerConfig, logger log.Logger) BackgroundWorker {
+func NewBackgroundWorker(config *config.WorkerConfig, logger log.Logger) (BackgroundWorker, error) {
 	switch config.Worker.Type {
 	case 0:
 		return newAsynqWorker(config, logger)
@@ -0,0 +1,107 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Worker combines a BackgroundEnqueuer and a BackgroundWorker behind a typed
+// API. Payloads are serialized with JSON.
+type Worker struct {
+	enqueuer BackgroundEnqueuer
+	worker   BackgroundWorker
+}
+
+// A Worker constructor. Called automatically by fx and bootstrapper.
+func NewWorker(enqueuer BackgroundEnqueuer, worker BackgroundWorker) *Worker {
+	return &Worker{
+		enqueuer: enqueuer,
+		worker:   worker,
+	}
+}
+
+// Enqueue marshals payload and enqueues a new task of typename.
+func (w *Worker) Enqueue(ctx context.Context, typename string, payload any, opts ...EnqueuerOption) error {
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return w.enqueuer.EnqueueContext(ctx, typename, buf, opts...)
+}
+
+// Register sets a typename handler on the server side.
+func (w *Worker) Register(typename string, handler func(ctx context.Context, payload []byte) error, cleanups ...func(taskID string, payload []byte)) {
+	w.worker.Register(typename, handler, cleanups...)
+}
+
+// Run starts processing registered tasks.
+func (w *Worker) Run() {
+	w.worker.Run()
+}
+
+// ListArchived lists tasks which exhausted their retries.
+func (w *Worker) ListArchived(opts ...InspectOption) ([]FailedTask, error) {
+	inspector, ok := w.enqueuer.(BackgroundInspector)
+	if !ok {
+		return nil, ErrInspectionNotSupported
+	}
+
+	return inspector.ListArchived(opts...)
+}
+
+// ListRetry lists failed tasks awaiting a retry.
+func (w *Worker) ListRetry(opts ...InspectOption) ([]FailedTask, error) {
+	inspector, ok := w.enqueuer.(BackgroundInspector)
+	if !ok {
+		return nil, ErrInspectionNotSupported
+	}
+
+	return inspector.ListRetry(opts...)
+}
+
+// Requeue schedules an archived or a retry task for immediate processing.
+func (w *Worker) Requeue(taskID string, opts ...InspectOption) error {
+	inspector, ok := w.enqueuer.(BackgroundInspector)
+	if !ok {
+		return ErrInspectionNotSupported
+	}
+
+	return inspector.Requeue(taskID, opts...)
+}
+
+// Close closes the underlying enqueuer.
+func (w *Worker) Close() error {
+	return w.enqueuer.Close()
+}
+
+// JSONHandler adapts a typed handler to a raw payload handler by
+// unmarshaling task payloads into T.
+func JSONHandler[T any](handler func(ctx context.Context, payload T) error) func(ctx context.Context, payload []byte) error {
+	return func(ctx context.Context, payload []byte) error {
+		var val T
+		if err := json.Unmarshal(payload, &val); err != nil {
+			return err
+		}
+
+		return handler(ctx, val)
+	}
+}
@@ -0,0 +1,110 @@
+/**
+ *
+ * (c) Copyright Ascensio System SIA 2024
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// memoryBackend is an in-process BackgroundEnqueuer and BackgroundWorker
+// processing tasks synchronously on enqueue.
+type memoryBackend struct {
+	handlers map[string]func(ctx context.Context, payload []byte) error
+	options  []EnqueuerOptions
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{handlers: make(map[string]func(ctx context.Context, payload []byte) error)}
+}
+
+func (b *memoryBackend) Register(pattern string, handler func(ctx context.Context, payload []byte) error, cleanups ...func(taskID string, payload []byte)) {
+	b.handlers[pattern] = handler
+}
+
+func (b *memoryBackend) Run() {}
+
+func (b *memoryBackend) Enqueue(pattern string, task []byte, opts ...EnqueuerOption) error {
+	return b.EnqueueContext(context.Background(), pattern, task, opts...)
+}
+
+func (b *memoryBackend) EnqueueContext(ctx context.Context, pattern string, task []byte, opts ...EnqueuerOption) error {
+	b.options = append(b.options, NewEnqueuerOptions(opts...))
+	handler, ok := b.handlers[pattern]
+	if !ok {
+		return errors.New("no handler registered")
+	}
+
+	return handler(ctx, task)
+}
+
+func (b *memoryBackend) Close() error {
+	return nil
+}
+
+type conversionTask struct {
+	FileID string `json:"file_id"`
+	Format string `json:"format"`
+}
+
+func TestWorkerEnqueueProcess(t *testing.T) {
+	backend := newMemoryBackend()
+	w := NewWorker(backend, backend)
+
+	var processed conversionTask
+	w.Register("conversion", JSONHandler(func(ctx context.Context, payload conversionTask) error {
+		processed = payload
+		return nil
+	}))
+	w.Run()
+
+	if err := w.Enqueue(
+		context.Background(), "conversion", conversionTask{FileID: "1", Format: "pdf"},
+		WithQueue("critical"), WithMaxRetry(5),
+	); err != nil {
+		t.Fatalf("could not enqueue a task: %s", err.Error())
+	}
+
+	if processed.FileID != "1" || processed.Format != "pdf" {
+		t.Fatalf("expected the task payload to be processed, got %+v", processed)
+	}
+
+	if backend.options[0].Queue != "critical" || backend.options[0].MaxRetry != 5 {
+		t.Fatalf("expected enqueue options to be passed through, got %+v", backend.options[0])
+	}
+}
+
+func TestWorkerEnqueueInvalidPayload(t *testing.T) {
+	backend := newMemoryBackend()
+	if err := NewWorker(backend, backend).Enqueue(context.Background(), "conversion", make(chan int)); err == nil {
+		t.Fatal("expected an unmarshalable payload to be rejected")
+	}
+
+	if len(backend.options) != 0 {
+		t.Fatal("expected nothing to be enqueued")
+	}
+}
+
+func TestWorkerInspectionNotSupported(t *testing.T) {
+	backend := newMemoryBackend()
+	if _, err := NewWorker(backend, backend).ListArchived(); !errors.Is(err, ErrInspectionNotSupported) {
+		t.Fatalf("expected an inspection not supported error, got %v", err)
+	}
+}